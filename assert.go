@@ -0,0 +1,25 @@
+package multierr
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// AssertContains fails the test if err does not contain target (according to
+// Contains), listing all suberrors of err in the failure message.
+func AssertContains(t testing.TB, err, target error) {
+	t.Helper()
+	if Contains(err, target) {
+		return
+	}
+	if err == nil {
+		t.Errorf("expected error %q, got nil", target)
+		return
+	}
+	var buf strings.Builder
+	EachIndexed(err, func(i int, err error) {
+		fmt.Fprintf(&buf, "\n(%d) %s", i+1, strings.ReplaceAll(err.Error(), "\n", "\n\t"))
+	})
+	t.Errorf("expected error %q, got %d error(s):%s", target, Len(err), buf.String())
+}
@@ -0,0 +1,48 @@
+package multierr_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/andreyvit/multierr"
+)
+
+type fakeTB struct {
+	testing.TB
+	failures []string
+}
+
+func (t *fakeTB) Helper() {}
+
+func (t *fakeTB) Errorf(format string, args ...any) {
+	t.failures = append(t.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertContains(t *testing.T) {
+	tests := []struct {
+		err    error
+		target error
+		e      string
+	}{
+		{multierr.Append(oops, fmt.Errorf("wrapped: %w", whoops)), whoops, ""},
+		{oops, oops, ""},
+		{multierr.Append(oops, whoops), whoopsie, "expected error \"whoopsie\", got 2 error(s):\n(1) oops\n(2) whoops"},
+		{oops, whoopsie, "expected error \"whoopsie\", got 1 error(s):\n(1) oops"},
+		{nil, whoopsie, "expected error \"whoopsie\", got nil"},
+	}
+	for _, tt := range tests {
+		ft := &fakeTB{}
+		multierr.AssertContains(ft, tt.err, tt.target)
+
+		var a string
+		if len(ft.failures) > 0 {
+			a = ft.failures[0]
+		}
+		if len(ft.failures) > 1 {
+			t.Errorf("AssertContains(%v, %v) failed %d times, wanted at most once", tt.err, tt.target, len(ft.failures))
+		}
+		if a != tt.e {
+			t.Errorf("AssertContains(%v, %v) failure = %q, wanted %q", tt.err, tt.target, a, tt.e)
+		}
+	}
+}
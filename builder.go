@@ -0,0 +1,148 @@
+package multierr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Builder accumulates errors, optionally retaining only a limited number of
+// them to keep memory usage bounded. The zero value is ready to use.
+//
+// A Builder is not safe for concurrent use.
+type Builder struct {
+	// Limit is the maximum number of errors to retain. Once it is reached,
+	// further errors are counted but not stored, and Err reports them via
+	// a trailing "and N more errors" error. Zero means no limit.
+	Limit int
+
+	// Prefix, if set, is prepended to the message of each error returned by
+	// Err, like WithPrefix does. It is applied at the time Err is called,
+	// so it can be set or changed after adding errors.
+	Prefix string
+
+	errs    []error
+	dropped int
+}
+
+// Append adds err to the builder. Suberrors of a multierror are added
+// individually. A nil err is a no-op.
+func (b *Builder) Append(err error) {
+	ForEach(err, func(err error) {
+		if b.Limit > 0 && len(b.errs) >= b.Limit {
+			b.dropped++
+		} else {
+			b.errs = append(b.errs, err)
+		}
+	})
+}
+
+// Grow grows the builder's capacity, if necessary, to guarantee space for
+// another n errors. Use it to avoid repeated reallocations when the number of
+// errors is known in advance.
+func (b *Builder) Grow(n int) {
+	if b.Limit > 0 && len(b.errs)+n > b.Limit {
+		n = b.Limit - len(b.errs)
+	}
+	if n > 0 {
+		b.errs = slices.Grow(b.errs, n)
+	}
+}
+
+// Err returns the accumulated errors combined into a single error, or nil if
+// none were added. The builder can continue to be used afterwards.
+func (b *Builder) Err() error {
+	n := len(b.errs)
+	if b.dropped > 0 {
+		n++
+	}
+	errs := make([]error, len(b.errs), n)
+	copy(errs, b.errs)
+	if b.Prefix != "" {
+		for i, err := range errs {
+			errs[i] = fmt.Errorf("%s: %w", b.Prefix, err)
+		}
+	}
+	if b.dropped > 0 {
+		errs = append(errs, moreErrors(b.dropped))
+	}
+	return collapse(errs)
+}
+
+// Reset removes all accumulated errors, keeping the allocated capacity and
+// the settings (like Limit) for reuse.
+func (b *Builder) Reset() {
+	clear(b.errs)
+	b.errs = b.errs[:0]
+	b.dropped = 0
+}
+
+// maxPooledCap is the largest capacity of a builder to keep in the pool,
+// so that occasional huge aggregates don't stay in memory forever.
+const maxPooledCap = 64
+
+var builderPool = sync.Pool{
+	New: func() any {
+		return new(Builder)
+	},
+}
+
+// GetBuilder returns an empty Builder from a pool, reusing memory of
+// the builders previously released via PutBuilder. This reduces allocations
+// in servers that build and discard many small aggregates.
+func GetBuilder() *Builder {
+	return builderPool.Get().(*Builder)
+}
+
+// PutBuilder resets b, including its settings, and returns it to the pool used
+// by GetBuilder. b must not be used afterwards. Errors previously returned by
+// b.Err remain valid, because Err always returns a copy.
+func PutBuilder(b *Builder) {
+	if cap(b.errs) > maxPooledCap {
+		return
+	}
+	b.Reset()
+	*b = Builder{errs: b.errs}
+	builderPool.Put(b)
+}
+
+// moreErrors returns a placeholder error standing for n omitted errors.
+func moreErrors(n int) error {
+	if n == 1 {
+		return errors.New("and 1 more error")
+	}
+	return fmt.Errorf("and %d more errors", n)
+}
+
+// TypedBuilder accumulates errors of a single concrete type T, keeping them
+// available with their type intact via Typed. The zero value is ready to use.
+//
+// A TypedBuilder is not safe for concurrent use.
+type TypedBuilder[T error] struct {
+	errs []T
+}
+
+// Append adds err to the builder. A nil interface value is a no-op; note that
+// a typed nil pointer is not nil as an error, and is added.
+func (b *TypedBuilder[T]) Append(err T) {
+	if error(err) == nil {
+		return
+	}
+	b.errs = append(b.errs, err)
+}
+
+// Typed returns a copy of the accumulated errors.
+func (b *TypedBuilder[T]) Typed() []T {
+	return slices.Clone(b.errs)
+}
+
+// Err returns the accumulated errors combined into a single error, or nil if
+// none were added. The builder can continue to be used afterwards.
+func (b *TypedBuilder[T]) Err() error {
+	errs := make([]error, len(b.errs))
+	for i, err := range b.errs {
+		errs[i] = err
+	}
+	return collapse(errs)
+}
@@ -0,0 +1,177 @@
+package multierr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/andreyvit/multierr"
+)
+
+func ExampleBuilder() {
+	b := multierr.Builder{Limit: 3}
+	for i := 1; i <= 10; i++ {
+		b.Append(fmt.Errorf("failure %d", i))
+	}
+	b.Append(nil)
+
+	err := b.Err()
+	fmt.Println(multierr.Len(err))
+	fmt.Println(err)
+
+	// Output: 4
+	// 4 errors occurred:
+	// (1) failure 1
+	// (2) failure 2
+	// (3) failure 3
+	// (4) and 7 more errors
+}
+
+func ExampleBuilder_Grow() {
+	var b multierr.Builder
+	b.Grow(3)
+	b.Append(oops)
+	b.Append(whoops)
+	b.Append(whoopsie)
+
+	fmt.Println(multierr.All(b.Err()))
+	// Output: [oops whoops whoopsie]
+}
+
+func BenchmarkBuilder(b *testing.B) {
+	errs := benchmarkErrors(16)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var eb multierr.Builder
+		for _, err := range errs {
+			eb.Append(err)
+		}
+		_ = eb.Err()
+	}
+}
+
+func BenchmarkBuilder_Grow(b *testing.B) {
+	errs := benchmarkErrors(16)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var eb multierr.Builder
+		eb.Grow(len(errs))
+		for _, err := range errs {
+			eb.Append(err)
+		}
+		_ = eb.Err()
+	}
+}
+
+type validationError struct {
+	field string
+}
+
+func (e *validationError) Error() string {
+	return e.field + " is invalid"
+}
+
+func ExampleTypedBuilder() {
+	var b multierr.TypedBuilder[*validationError]
+	b.Append(&validationError{"email"})
+	b.Append(&validationError{"age"})
+
+	for _, err := range b.Typed() {
+		fmt.Println(err.field)
+	}
+	fmt.Println(b.Err())
+
+	// Output: email
+	// age
+	// 2 errors occurred:
+	// (1) email is invalid
+	// (2) age is invalid
+}
+
+func ExampleBuilder_Reset() {
+	b := multierr.Builder{Limit: 1}
+	b.Append(oops)
+	b.Append(whoops)
+	err := b.Err()
+
+	b.Reset()
+	fmt.Println(b.Err())
+
+	b.Append(whoopsie)
+	b.Append(io.EOF)
+	fmt.Println(multierr.All(b.Err()))
+	fmt.Println(multierr.All(err))
+
+	// Output: <nil>
+	// [whoopsie and 1 more error]
+	// [oops and 1 more error]
+}
+
+func TestGetBuilder(t *testing.T) {
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		g := g
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				b := multierr.GetBuilder()
+				if err := b.Err(); err != nil {
+					t.Errorf("pooled builder not empty: %v", err)
+				}
+				b.Append(fmt.Errorf("g%d: failure %d", g, i))
+				b.Append(oops)
+				err := b.Err()
+				multierr.PutBuilder(b)
+
+				if a, e := err.Error(), fmt.Sprintf("2 errors occurred:\n(1) g%d: failure %d\n(2) oops", g, i); a != e {
+					t.Errorf("Err = %q, wanted %q", a, e)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkGetBuilder(b *testing.B) {
+	errs := benchmarkErrors(4)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		eb := multierr.GetBuilder()
+		for _, err := range errs {
+			eb.Append(err)
+		}
+		_ = eb.Err()
+		multierr.PutBuilder(eb)
+	}
+}
+
+func BenchmarkBuilder_small(b *testing.B) {
+	errs := benchmarkErrors(4)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var eb multierr.Builder
+		for _, err := range errs {
+			eb.Append(err)
+		}
+		_ = eb.Err()
+	}
+}
+
+func ExampleBuilder_prefix() {
+	var b multierr.Builder
+	b.Append(oops)
+	b.Append(whoops)
+	b.Prefix = "upload"
+
+	err := b.Err()
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, whoops))
+
+	// Output: 2 errors occurred:
+	// (1) upload: oops
+	// (2) upload: whoops
+	// true
+}
@@ -0,0 +1,58 @@
+package multierr
+
+import (
+	"fmt"
+	"io"
+)
+
+// DeferClose closes c and appends the error it returns (if any) to the error
+// dest points to. It is meant to be deferred in functions with a named error
+// result, replacing the usual closure:
+//
+//	defer multierr.DeferClose(&err, f)
+//
+// A nil c is a no-op.
+func DeferClose(dest *error, c io.Closer) {
+	if c == nil {
+		return
+	}
+	AppendInto(dest, c.Close())
+}
+
+// DeferCloseNamed is like DeferClose, but prefixes the close error with
+// the given name, as in "name: <error>", to tell which resource failed.
+// The original error remains reachable via errors.Is and errors.As.
+func DeferCloseNamed(dest *error, name string, c io.Closer) {
+	if c == nil {
+		return
+	}
+	if err := c.Close(); err != nil {
+		AppendInto(dest, fmt.Errorf("%s: %w", name, err))
+	}
+}
+
+// CloseAll closes each of closers in order, even if some of them fail, and
+// returns all the close errors combined into a single error. Nil closers
+// are skipped.
+func CloseAll(closers ...io.Closer) error {
+	var result error
+	for _, c := range closers {
+		if c != nil {
+			result = Append(result, c.Close())
+		}
+	}
+	return result
+}
+
+// CloseAllReverse is like CloseAll, but closes closers in reverse order (last
+// one first), matching the order in which deferred calls would run. Errors are
+// combined in the order they occur.
+func CloseAllReverse(closers ...io.Closer) error {
+	var result error
+	for i := len(closers) - 1; i >= 0; i-- {
+		if c := closers[i]; c != nil {
+			result = Append(result, c.Close())
+		}
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+package multierr_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/andreyvit/multierr"
+)
+
+type closer struct {
+	name   string
+	err    error
+	closed *[]string
+}
+
+func (c *closer) Close() error {
+	if c.closed != nil {
+		*c.closed = append(*c.closed, c.name)
+	}
+	return c.err
+}
+
+func ExampleDeferClose() {
+	process := func(c *closer) (err error) {
+		defer multierr.DeferClose(&err, c)
+		defer multierr.DeferClose(&err, nil)
+		return errors.New("magic not available")
+	}
+
+	fmt.Println(process(&closer{err: nil}))
+	fmt.Println(process(&closer{err: errors.New("close: whoopsie")}))
+
+	// Output: magic not available
+	// 2 errors occurred:
+	// (1) magic not available
+	// (2) close: whoopsie
+}
+
+func ExampleDeferCloseNamed() {
+	process := func(in, out *closer) (err error) {
+		defer multierr.DeferCloseNamed(&err, "close input", in)
+		defer multierr.DeferCloseNamed(&err, "close output", out)
+		return nil
+	}
+
+	err := process(&closer{err: whoopsie}, &closer{})
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, whoopsie))
+
+	// Output: close input: whoopsie
+	// true
+}
+
+func ExampleCloseAll() {
+	var closed []string
+	err := multierr.CloseAll(
+		&closer{name: "a", closed: &closed},
+		&closer{name: "b", err: oops, closed: &closed},
+		nil,
+		&closer{name: "c", closed: &closed},
+		&closer{name: "d", err: whoops, closed: &closed},
+	)
+	fmt.Println(closed)
+	fmt.Println(err)
+
+	// Output: [a b c d]
+	// 2 errors occurred:
+	// (1) oops
+	// (2) whoops
+}
+
+func ExampleCloseAllReverse() {
+	var closed []string
+	err := multierr.CloseAllReverse(
+		&closer{name: "db", err: oops, closed: &closed},
+		nil,
+		&closer{name: "cache", closed: &closed},
+		&closer{name: "conn", err: whoops, closed: &closed},
+	)
+	fmt.Println(closed)
+	fmt.Println(err)
+
+	// Output: [conn cache db]
+	// 2 errors occurred:
+	// (1) whoops
+	// (2) oops
+}
@@ -3,6 +3,7 @@ package multierr_test
 import (
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/andreyvit/multierr"
 )
@@ -32,3 +33,31 @@ func (d Dummy) DoSomeMagic() error {
 func (d Dummy) Close() error {
 	return errors.New("close: whoopsie")
 }
+
+func ExampleRecover() {
+	run := func(f func() error) (err error) {
+		defer multierr.Recover(&err)
+		defer func() {
+			err = multierr.Append(err, errors.New("close: whoopsie"))
+		}()
+		return f()
+	}
+
+	fmt.Println(run(func() error {
+		return nil
+	}))
+	fmt.Println(run(func() error {
+		panic("boom")
+	}))
+
+	err := run(func() error {
+		panic(os.ErrNotExist)
+	})
+	fmt.Println(multierr.Last(err) == os.ErrNotExist)
+
+	// Output: close: whoopsie
+	// 2 errors occurred:
+	// (1) close: whoopsie
+	// (2) panic: boom
+	// true
+}
@@ -0,0 +1,5 @@
+package multierr
+
+// Multi exposes the multierror type to tests, allowing to construct
+// values that break its invariants.
+type Multi = multi
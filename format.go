@@ -0,0 +1,386 @@
+package multierr
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// FormatMessage is a function used to format a string with multiple error messages.
+// You can replace it if your project calls for a different format.
+// Note that this is a global setting and should be left to the end user to decide.
+var FormatMessage func(errs []error) string = DefaultFormatMessage
+
+// Indent is inserted by DefaultFormatMessage at the start of continuation lines
+// of multi-line error messages. Like FormatMessage, this is a global setting
+// and should be left to the end user to decide.
+var Indent = "\t"
+
+// ShowCountHeader controls whether DefaultFormatMessage starts with
+// an "N errors occurred:" line. Like FormatMessage, this is a global setting
+// and should be left to the end user to decide.
+var ShowCountHeader = true
+
+// Labeler is implemented by errors that carry a label, like the name of
+// the field or the file they relate to. DefaultFormatMessage prints the label
+// in place of the error's number, also when the labeled error is wrapped.
+type Labeler interface {
+	Label() string
+}
+
+// findLabeler is like errors.As(err, &l) for a Labeler l, but stops at errors
+// already visited higher up in the chain, so that it terminates for cyclic
+// chains, which DefaultFormatMessage can otherwise print fine.
+func findLabeler(path []uintptr, err error) (Labeler, bool) {
+	path, ok := enterPath(path, err)
+	if !ok {
+		return nil, false
+	}
+	if l, ok := err.(Labeler); ok {
+		return l, true
+	}
+	var l Labeler
+	if x, ok := err.(interface{ As(any) bool }); ok && x.As(&l) {
+		return l, true
+	}
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, err := range e.Unwrap() {
+			if err != nil {
+				if l, ok := findLabeler(path, err); ok {
+					return l, true
+				}
+			}
+		}
+	case interface{ Unwrap() error }:
+		if inner := e.Unwrap(); inner != nil {
+			return findLabeler(path, inner)
+		}
+	}
+	return nil, false
+}
+
+// enterPath appends err to path, the pointers of the errors being visited
+// by a recursive walk, unless err is already on it, which means that the walk
+// has run into a cycle.
+func enterPath(path []uintptr, err error) ([]uintptr, bool) {
+	if v := reflect.ValueOf(err); v.Kind() == reflect.Pointer {
+		p := v.Pointer()
+		for _, q := range path {
+			if p == q {
+				return path, false
+			}
+		}
+		path = append(path, p)
+	}
+	return path, true
+}
+
+// MaxMessageLen, if positive, limits the length (in bytes) of each error message
+// printed by DefaultFormatMessage; longer messages are cut off with
+// "… (truncated)". Zero means no limit. Like FormatMessage, this is a global
+// setting and should be left to the end user to decide.
+var MaxMessageLen int
+
+// DefaultFormatMessage performs the default formatting of multiple error messages.
+func DefaultFormatMessage(errs []error) string {
+	var buf strings.Builder
+	WriteMessage(&buf, errs)
+	return buf.String()
+}
+
+// WriteMessage writes multiple error messages to w in the default format,
+// without building the entire message in memory first. Returns the number of
+// bytes written and the first write error encountered, if any.
+func WriteMessage(w io.Writer, errs []error) (int, error) {
+	return writeMessage(w, errs, messageStyle{msg: error.Error})
+}
+
+// LogfmtFormatMessage formats multiple error messages as a single quoted line,
+// safe to use as a value in logfmt (key=value) logs: the default format
+// is quoted with strconv.Quote, escaping quotes and newlines.
+//
+//	"2 errors occurred:\n(1) oops\n(2) whoops"
+func LogfmtFormatMessage(errs []error) string {
+	return strconv.Quote(DefaultFormatMessage(errs))
+}
+
+// SummaryFormatMessage formats multiple errors as just their count, like
+// "5 errors occurred", omitting the individual messages.
+func SummaryFormatMessage(errs []error) string {
+	return pluralErrors(len(errs)) + " occurred"
+}
+
+// TreeFormatMessage is like DefaultFormatMessage, but also prints the chain
+// of errors wrapped by each suberror beneath it, indenting each level further.
+//
+//	2 errors occurred:
+//	(1) load config: open config.json: file does not exist
+//		open config.json: file does not exist
+//			file does not exist
+//	(2) oops
+func TreeFormatMessage(errs []error) string {
+	var buf strings.Builder
+	writeMessage(&buf, errs, messageStyle{msg: treeMessage})
+	return buf.String()
+}
+
+// treeMessage returns the message of err followed by the indented messages
+// of the errors it wraps, recursively. An error already printed higher up
+// in the chain is printed without its children, so that cyclic chains
+// terminate.
+func treeMessage(err error) string {
+	return treeMessageWithin(nil, err)
+}
+
+func treeMessageWithin(path []uintptr, err error) string {
+	path, ok := enterPath(path, err)
+	if !ok {
+		return err.Error()
+	}
+
+	var children []error
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		children = e.Unwrap()
+	case interface{ Unwrap() error }:
+		children = []error{e.Unwrap()}
+	}
+
+	var buf strings.Builder
+	buf.WriteString(err.Error())
+	for _, child := range children {
+		if child != nil {
+			buf.WriteString("\n")
+			buf.WriteString(strings.ReplaceAll(treeMessageWithin(path, child), "\n", "\n"+Indent))
+		}
+	}
+	return buf.String()
+}
+
+// NoColor disables ANSI escape sequences in ColorFormatMessage, making it
+// produce the same output as DefaultFormatMessage. Set it when the output is
+// not a terminal.
+var NoColor = false
+
+const (
+	ansiBold   = "\x1b[1m"
+	ansiYellow = "\x1b[33m"
+	ansiReset  = "\x1b[0m"
+)
+
+// ColorFormatMessage is like DefaultFormatMessage, but highlights the header
+// and the (n) markers using ANSI escape sequences, unless NoColor is set.
+// Assign it to FormatMessage in command-line tools.
+func ColorFormatMessage(errs []error) string {
+	var buf strings.Builder
+	writeMessage(&buf, errs, messageStyle{msg: error.Error, color: !NoColor})
+	return buf.String()
+}
+
+// messageStyle customizes the output of writeMessage.
+type messageStyle struct {
+	// msg returns the text of each error.
+	msg func(err error) string
+
+	// color enables ANSI highlighting of the header and markers.
+	color bool
+
+	// total is the number of errors reported in the header, if different
+	// from len(errs), e.g. when errs have been truncated.
+	total int
+}
+
+// writeMessage writes errs in the default format, adjusted according to style.
+func writeMessage(w io.Writer, errs []error, style messageStyle) (int, error) {
+	var bold, yellow, reset string
+	if style.color {
+		bold, yellow, reset = ansiBold, ansiYellow, ansiReset
+	}
+
+	total := style.total
+	if total == 0 {
+		total = len(errs)
+	}
+
+	var n int
+	if ShowCountHeader {
+		m, err := fmt.Fprintf(w, "%s%s occurred:%s\n", bold, pluralErrors(total), reset)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	for i, e := range errs {
+		sep := "\n"
+		if i == 0 {
+			sep = ""
+		}
+		marker := strconv.Itoa(i + 1)
+		if l, ok := findLabeler(nil, e); ok {
+			marker = l.Label()
+		}
+		s := truncateMessage(style.msg(e))
+		m, err := fmt.Fprintf(w, "%s%s(%s)%s %s", sep, yellow, marker, reset, strings.ReplaceAll(s, "\n", "\n"+Indent))
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// writeFormatted writes msg to f the way fmt formats a string for the given
+// verb, flags and width. For %v and %s, the precision is not applied, since
+// a multierror uses it to limit the number of suberrors instead.
+func writeFormatted(f fmt.State, verb rune, msg string) {
+	directive := fmt.FormatString(f, verb)
+	if verb == 'v' || verb == 's' {
+		if i := strings.IndexByte(directive, '.'); i >= 0 {
+			directive = directive[:i] + string(verb)
+		}
+	}
+	fmt.Fprintf(f, directive, msg)
+}
+
+// truncateMessage cuts s to MaxMessageLen bytes, without splitting a UTF-8
+// sequence.
+func truncateMessage(s string) string {
+	if MaxMessageLen <= 0 || len(s) <= MaxMessageLen {
+		return s
+	}
+	n := MaxMessageLen
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "… (truncated)"
+}
+
+// pluralErrors returns "1 error" or "N errors".
+func pluralErrors(n int) string {
+	if n == 1 {
+		return "1 error"
+	}
+	return strconv.Itoa(n) + " errors"
+}
+
+// Format formats err using the given formatter instead of the global
+// FormatMessage. Returns err.Error() if err is not a multierror type,
+// and an empty string if err is nil.
+func Format(err error, format func(errs []error) string) string {
+	if err == nil {
+		return ""
+	} else if m, ok := toMulti(err); ok && len(m) > 1 {
+		return format([]error(m))
+	} else {
+		return err.Error()
+	}
+}
+
+// SingleLineFormatMessage formats multiple error messages on a single line,
+// like "2 errors: oops; whoops", replacing any newlines within the messages
+// with spaces. Assign it to FormatMessage or pass it to Format to use it.
+func SingleLineFormatMessage(errs []error) string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%s: ", pluralErrors(len(errs)))
+	for i, err := range errs {
+		if i > 0 {
+			buf.WriteString("; ")
+		}
+		buf.WriteString(strings.ReplaceAll(err.Error(), "\n", " "))
+	}
+	return buf.String()
+}
+
+// ParseDefaultMessage splits a message produced by DefaultFormatMessage back
+// into the individual error messages, joining continuation lines (indented by
+// Indent) with the entry they belong to. Returns false if s is not in the
+// default format, e.g. when it doesn't start with an "N errors occurred:" line.
+// If ShowCountHeader is false, the header is not expected.
+//
+// Labels (see Labeler) containing ") " cannot be told apart from the message,
+// so for them the message is split at the first ") " of the entry.
+func ParseDefaultMessage(s string) ([]string, bool) {
+	count := -1
+	body := s
+	if ShowCountHeader {
+		header, rest, ok := strings.Cut(s, "\n")
+		if !ok {
+			return nil, false
+		}
+		countStr, ok := strings.CutSuffix(header, " errors occurred:")
+		if !ok {
+			countStr, ok = strings.CutSuffix(header, " error occurred:")
+			if !ok || countStr != "1" {
+				return nil, false
+			}
+		}
+		n, err := strconv.Atoi(countStr)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		count, body = n, rest
+	}
+	if body == "" {
+		return nil, count <= 0
+	}
+
+	var msgs []string
+	for _, line := range strings.Split(body, "\n") {
+		if cont, ok := strings.CutPrefix(line, Indent); ok && len(msgs) > 0 && Indent != "" {
+			msgs[len(msgs)-1] += "\n" + cont
+		} else if strings.HasPrefix(line, "(") {
+			_, msg, ok := strings.Cut(line, ") ")
+			if !ok {
+				return nil, false
+			}
+			msgs = append(msgs, msg)
+		} else {
+			return nil, false
+		}
+	}
+	if count >= 0 && len(msgs) != count {
+		return nil, false
+	}
+	return msgs, true
+}
+
+var (
+	formatsMu sync.Mutex
+	formats   = map[string]func(errs []error) string{
+		"default":     DefaultFormatMessage,
+		"single-line": SingleLineFormatMessage,
+		"summary":     SummaryFormatMessage,
+		"color":       ColorFormatMessage,
+		"tree":        TreeFormatMessage,
+		"logfmt":      LogfmtFormatMessage,
+	}
+)
+
+// RegisterFormat makes a formatter available to SetFormatByName under the given
+// name, replacing any formatter previously registered with it. The built-in
+// formatters are registered as "default", "single-line", "summary", "color",
+// "tree" and "logfmt".
+func RegisterFormat(name string, format func(errs []error) string) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	formats[name] = format
+}
+
+// SetFormatByName sets FormatMessage to the formatter registered under
+// the given name, e.g. when the format is chosen in a config file.
+// Returns an error if there's no such formatter.
+func SetFormatByName(name string) error {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	format := formats[name]
+	if format == nil {
+		return fmt.Errorf("multierr: unknown format %q", name)
+	}
+	FormatMessage = format
+	return nil
+}
@@ -0,0 +1,248 @@
+package multierr_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/andreyvit/multierr"
+)
+
+func ExampleFormat() {
+	joinMessages := func(errs []error) string {
+		var msgs []string
+		for _, err := range errs {
+			msgs = append(msgs, err.Error())
+		}
+		return strings.Join(msgs, " | ")
+	}
+
+	err := multierr.Append(oops, whoops)
+	fmt.Println(multierr.Format(err, joinMessages))
+	fmt.Println(multierr.Format(oops, joinMessages))
+	fmt.Printf("%q\n", multierr.Format(nil, joinMessages))
+	fmt.Println(err)
+
+	// Output: oops | whoops
+	// oops
+	// ""
+	// 2 errors occurred:
+	// (1) oops
+	// (2) whoops
+}
+
+func ExampleSingleLineFormatMessage() {
+	err := multierr.Append(oops, errors.New("whoops\nat line 2"))
+	fmt.Println(multierr.Format(err, multierr.SingleLineFormatMessage))
+
+	// Output: 2 errors: oops; whoops at line 2
+}
+
+func ExampleWriteMessage() {
+	errs := []error{oops, errors.New("whoops\nat line 2"), whoopsie}
+
+	var buf bytes.Buffer
+	n, err := multierr.WriteMessage(&buf, errs)
+	fmt.Println(n == buf.Len(), err)
+	fmt.Println(buf.String() == multierr.DefaultFormatMessage(errs))
+	fmt.Println(buf.String())
+
+	// Output: true <nil>
+	// true
+	// 3 errors occurred:
+	// (1) oops
+	// (2) whoops
+	// 	at line 2
+	// (3) whoopsie
+}
+
+func ExampleIndent() {
+	defer func(old string) { multierr.Indent = old }(multierr.Indent)
+	multierr.Indent = "  "
+
+	fmt.Println(multierr.Append(oops, errors.New("whoops\nat line 2")))
+
+	// Output: 2 errors occurred:
+	// (1) oops
+	// (2) whoops
+	//   at line 2
+}
+
+func ExampleColorFormatMessage() {
+	errs := []error{oops, whoops}
+	fmt.Printf("%q\n", multierr.ColorFormatMessage(errs))
+
+	defer func(old bool) { multierr.NoColor = old }(multierr.NoColor)
+	multierr.NoColor = true
+	fmt.Println(multierr.ColorFormatMessage(errs) == multierr.DefaultFormatMessage(errs))
+
+	// Output: "\x1b[1m2 errors occurred:\x1b[0m\n\x1b[33m(1)\x1b[0m oops\n\x1b[33m(2)\x1b[0m whoops"
+	// true
+}
+
+func ExampleSummaryFormatMessage() {
+	fmt.Println(multierr.SummaryFormatMessage([]error{oops, whoops, whoopsie}))
+	fmt.Println(multierr.SummaryFormatMessage(nil))
+
+	// Output: 3 errors occurred
+	// 0 errors occurred
+}
+
+func ExampleShowCountHeader() {
+	err := multierr.Append(oops, whoops)
+	fmt.Println(err)
+
+	defer func(old bool) { multierr.ShowCountHeader = old }(multierr.ShowCountHeader)
+	multierr.ShowCountHeader = false
+	fmt.Println(err)
+
+	// Output: 2 errors occurred:
+	// (1) oops
+	// (2) whoops
+	// (1) oops
+	// (2) whoops
+}
+
+func ExampleTreeFormatMessage() {
+	load := fmt.Errorf("load config: %w", &os.PathError{Op: "open", Path: "config.json", Err: os.ErrNotExist})
+	fmt.Println(multierr.TreeFormatMessage([]error{load, oops}))
+
+	cyclic := &cyclicError{}
+	cyclic.next = &cyclicError{cyclic}
+	fmt.Println(multierr.TreeFormatMessage([]error{oops, cyclic}))
+
+	// Output: 2 errors occurred:
+	// (1) load config: open config.json: file does not exist
+	// 	open config.json: file does not exist
+	// 		file does not exist
+	// (2) oops
+	// 2 errors occurred:
+	// (1) oops
+	// (2) cyclic
+	// 	cyclic
+	// 		cyclic
+}
+
+type fieldError struct {
+	field string
+	msg   string
+}
+
+func (e *fieldError) Error() string { return e.msg }
+func (e *fieldError) Label() string { return e.field }
+
+func ExampleLabeler() {
+	err := multierr.Combine(&fieldError{"email", "is required"}, oops, &fieldError{"age", "must be positive"})
+	fmt.Println(err)
+
+	// Output: 3 errors occurred:
+	// (email) is required
+	// (2) oops
+	// (age) must be positive
+}
+
+func ExampleLabeler_wrapped() {
+	err := multierr.AppendWithLabel(oops, "email", errors.New("is required"))
+	fmt.Println(multierr.WithPrefix(err, "signup"))
+
+	cyclic := &cyclicError{}
+	cyclic.next = &cyclicError{cyclic}
+	fmt.Println(multierr.Append(err, cyclic))
+
+	// Output: 2 errors occurred:
+	// (1) signup: oops
+	// (email) signup: is required
+	// 3 errors occurred:
+	// (1) oops
+	// (email) is required
+	// (3) cyclic
+}
+
+func ExampleParseDefaultMessage() {
+	err := multierr.Combine(oops, errors.New("whoops\n  at line 2\n\nsee above"), whoopsie)
+
+	msgs, ok := multierr.ParseDefaultMessage(err.Error())
+	fmt.Println(ok, len(msgs))
+	fmt.Printf("%q\n", msgs)
+
+	_, ok = multierr.ParseDefaultMessage("oops")
+	fmt.Println(ok)
+
+	msgs, ok = multierr.ParseDefaultMessage(multierr.DefaultFormatMessage(nil))
+	fmt.Println(ok, len(msgs))
+
+	// Output: true 3
+	// ["oops" "whoops\n  at line 2\n\nsee above" "whoopsie"]
+	// false
+	// true 0
+}
+
+func ExampleParseDefaultMessage_noHeader() {
+	defer func(old bool) { multierr.ShowCountHeader = old }(multierr.ShowCountHeader)
+	multierr.ShowCountHeader = false
+
+	err := multierr.Combine(oops, errors.New("whoops\n  at line 2"), whoopsie)
+	msgs, ok := multierr.ParseDefaultMessage(err.Error())
+	fmt.Println(ok, len(msgs))
+	fmt.Printf("%q\n", msgs)
+
+	// Output: true 3
+	// ["oops" "whoops\n  at line 2" "whoopsie"]
+}
+
+func ExampleLogfmtFormatMessage() {
+	err := multierr.Append(oops, errors.New(`bad "key=value"`+"\nat line 2"))
+	fmt.Printf("level=error err=%s\n", multierr.Format(err, multierr.LogfmtFormatMessage))
+
+	// Output: level=error err="2 errors occurred:\n(1) oops\n(2) bad \"key=value\"\n\tat line 2"
+}
+
+func ExampleSetFormatByName() {
+	defer func(old func([]error) string) { multierr.FormatMessage = old }(multierr.FormatMessage)
+
+	multierr.RegisterFormat("count", func(errs []error) string {
+		return fmt.Sprintf("%d failures", len(errs))
+	})
+	err := multierr.Append(oops, whoops)
+
+	fmt.Println(multierr.SetFormatByName("count"), err)
+	fmt.Println(multierr.SetFormatByName("single-line"), err)
+	fmt.Println(multierr.SetFormatByName("fancy"), err)
+
+	// Output: <nil> 2 failures
+	// <nil> 2 errors: oops; whoops
+	// multierr: unknown format "fancy" 2 errors: oops; whoops
+}
+
+func ExampleDefaultFormatMessage() {
+	fmt.Println(multierr.DefaultFormatMessage([]error{oops}))
+	fmt.Println(multierr.DefaultFormatMessage([]error{oops, whoops}))
+	fmt.Println(multierr.SummaryFormatMessage([]error{oops}))
+	fmt.Println(multierr.SingleLineFormatMessage([]error{oops}))
+
+	msgs, ok := multierr.ParseDefaultMessage(multierr.DefaultFormatMessage([]error{oops}))
+	fmt.Println(msgs, ok)
+
+	// Output: 1 error occurred:
+	// (1) oops
+	// 2 errors occurred:
+	// (1) oops
+	// (2) whoops
+	// 1 error occurred
+	// 1 error: oops
+	// [oops] true
+}
+
+func ExampleMaxMessageLen() {
+	defer func(old int) { multierr.MaxMessageLen = old }(multierr.MaxMessageLen)
+	multierr.MaxMessageLen = 10
+
+	err := multierr.Append(oops, errors.New(strings.Repeat("very long diff ", 100000)))
+	fmt.Println(err)
+
+	// Output: 2 errors occurred:
+	// (1) oops
+	// (2) very long … (truncated)
+}
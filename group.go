@@ -0,0 +1,140 @@
+package multierr
+
+import (
+	"context"
+	"sync"
+)
+
+// Group runs functions in goroutines and collects their errors. Unlike
+// errgroup, it does not cancel anything when a function fails; Wait returns
+// all the errors combined. The zero value is ready to use.
+type Group struct {
+	wg  sync.WaitGroup
+	mu  sync.Mutex
+	err error
+}
+
+// Go calls f in a new goroutine, appending the error it returns (if any)
+// to the group.
+func (g *Group) Go(f func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := f(); err != nil {
+			g.mu.Lock()
+			g.err = Append(g.err, err)
+			g.mu.Unlock()
+		}
+	}()
+}
+
+// Wait blocks until all functions started via Go have returned, and returns
+// their combined errors. The order of suberrors is the order in which the
+// functions finished, and is thus not deterministic.
+//
+// The group can be reused after Wait; the returned error is not affected
+// by the functions started later.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return Clone(g.err)
+}
+
+// Collector accumulates errors until its context is done. Once the context is
+// canceled, further errors are ignored, and Err reports the context's error
+// in addition to the ones collected so far. It is safe for concurrent use.
+type Collector struct {
+	ctx context.Context
+	mu  sync.Mutex
+	err error
+}
+
+// NewCollector returns a Collector that stops collecting when ctx is done.
+func NewCollector(ctx context.Context) *Collector {
+	return &Collector{ctx: ctx}
+}
+
+// Add appends err to the collected errors, unless the context is already done.
+// A nil err is a no-op.
+func (c *Collector) Add(err error) {
+	if err == nil || c.ctx.Err() != nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = Append(c.err, err)
+}
+
+// Err returns the collected errors combined into a single error, followed by
+// ctx.Err() if the context is done.
+func (c *Collector) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.ctx.Err(); err != nil {
+		return AppendNew(c.err, err)
+	}
+	return Clone(c.err)
+}
+
+type accumulatorKey struct{}
+
+type accumulator struct {
+	mu  sync.Mutex
+	err error
+}
+
+// WithAccumulator returns a context carrying an error accumulator, and a
+// function returning (a copy of) the errors added to it so far via Add. This allows
+// collecting non-fatal errors (like warnings) from anywhere in a call tree
+// without passing a pointer around. The accumulator is safe for concurrent use.
+func WithAccumulator(ctx context.Context) (context.Context, func() error) {
+	acc := &accumulator{}
+	return context.WithValue(ctx, accumulatorKey{}, acc), func() error {
+		acc.mu.Lock()
+		defer acc.mu.Unlock()
+		return Clone(acc.err)
+	}
+}
+
+// Add appends err to the accumulator of the given context, created by
+// WithAccumulator. Does nothing if err is nil or if ctx has no accumulator.
+func Add(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+	acc, _ := ctx.Value(accumulatorKey{}).(*accumulator)
+	if acc == nil {
+		return
+	}
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+	acc.err = Append(acc.err, err)
+}
+
+// Drain receives errors from ch until it is closed, and returns all non-nil
+// ones combined into a single error.
+func Drain(ch <-chan error) error {
+	var result error
+	for err := range ch {
+		result = Append(result, err)
+	}
+	return result
+}
+
+// DrainContext is like Drain, but also stops when ctx is done, in which case
+// ctx.Err() is appended after the errors received so far.
+func DrainContext(ctx context.Context, ch <-chan error) error {
+	var result error
+	for {
+		select {
+		case err, ok := <-ch:
+			if !ok {
+				return result
+			}
+			result = Append(result, err)
+		case <-ctx.Done():
+			return Append(result, ctx.Err())
+		}
+	}
+}
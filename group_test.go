@@ -0,0 +1,151 @@
+package multierr_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/andreyvit/multierr"
+)
+
+func TestGroup(t *testing.T) {
+	const n = 100
+	var g multierr.Group
+	for i := 0; i < n; i++ {
+		i := i
+		g.Go(func() error {
+			if i%2 == 0 {
+				return nil
+			}
+			return fmt.Errorf("failure %d", i)
+		})
+	}
+
+	err := g.Wait()
+	if a, e := multierr.Len(err), n/2; a != e {
+		t.Errorf("Len = %d, wanted %d", a, e)
+	}
+}
+
+func TestGroup_empty(t *testing.T) {
+	var g multierr.Group
+	if err := g.Wait(); err != nil {
+		t.Errorf("Wait = %v, wanted nil", err)
+	}
+}
+
+func TestGroup_reuse(t *testing.T) {
+	var g multierr.Group
+	for _, err := range []error{oops, whoops, whoopsie} {
+		err := err
+		g.Go(func() error { return err })
+		g.Wait()
+	}
+
+	mine := multierr.Append(g.Wait(), io.EOF)
+	g.Go(func() error { return io.ErrUnexpectedEOF })
+	if a, e := multierr.Len(g.Wait()), 4; a != e {
+		t.Errorf("Len(Wait) = %d, wanted %d", a, e)
+	}
+	if a, e := multierr.Last(mine), io.EOF; a != e {
+		t.Errorf("Last(mine) = %v, wanted %v", a, e)
+	}
+}
+
+func ExampleCollector() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := multierr.NewCollector(ctx)
+
+	c.Add(oops)
+	c.Add(nil)
+	c.Add(whoops)
+	fmt.Println(c.Err())
+
+	cancel()
+	c.Add(whoopsie)
+	fmt.Println(c.Err())
+	fmt.Println(multierr.Len(c.Err()))
+
+	// Output: 2 errors occurred:
+	// (1) oops
+	// (2) whoops
+	// 3 errors occurred:
+	// (1) oops
+	// (2) whoops
+	// (3) context canceled
+	// 3
+}
+
+func ExampleWithAccumulator() {
+	ctx, errs := multierr.WithAccumulator(context.Background())
+
+	multierr.Add(ctx, oops)
+	multierr.Add(ctx, nil)
+	multierr.Add(ctx, whoops)
+	multierr.Add(context.Background(), whoopsie)
+
+	fmt.Println(errs())
+	// Output: 2 errors occurred:
+	// (1) oops
+	// (2) whoops
+}
+
+func TestWithAccumulator(t *testing.T) {
+	const n = 100
+	ctx, errs := multierr.WithAccumulator(context.Background())
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			multierr.Add(ctx, fmt.Errorf("warning %d", i))
+			_ = errs()
+		}()
+	}
+	wg.Wait()
+
+	if a, e := multierr.Len(errs()), n; a != e {
+		t.Errorf("Len = %d, wanted %d", a, e)
+	}
+}
+
+func ExampleDrain() {
+	ch := make(chan error, 4)
+	ch <- nil
+	ch <- oops
+	ch <- nil
+	ch <- whoops
+	close(ch)
+	fmt.Println(multierr.Drain(ch))
+
+	empty := make(chan error)
+	close(empty)
+	fmt.Println(multierr.Drain(empty))
+
+	// Output: 2 errors occurred:
+	// (1) oops
+	// (2) whoops
+	// <nil>
+}
+
+func ExampleDrainContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan error)
+	go func() {
+		ch <- oops
+		ch <- nil
+		ch <- whoops
+		cancel()
+	}()
+
+	fmt.Println(multierr.DrainContext(ctx, ch))
+
+	// Output: 3 errors occurred:
+	// (1) oops
+	// (2) whoops
+	// (3) context canceled
+}
@@ -0,0 +1,270 @@
+package multierr
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Contains reports whether any suberror of err matches target according to
+// errors.Is. For a non-multierror err, this is the same as errors.Is(err, target).
+// Returns false if err is nil.
+func Contains(err, target error) bool {
+	var found bool
+	ForEachUntil(err, func(err error) bool {
+		found = errors.Is(err, target)
+		return !found
+	})
+	return found
+}
+
+// Find returns the first suberror of err for which pred returns true, or nil
+// if there is none. For a non-multierror err, pred is called with err itself.
+func Find(err error, pred func(err error) bool) error {
+	var found error
+	ForEachUntil(err, func(err error) bool {
+		if pred(err) {
+			found = err
+		}
+		return found == nil
+	})
+	return found
+}
+
+// AllMatchAny reports whether every suberror of err matches at least one of
+// the allowed errors according to errors.Is, e.g. to check that all failures
+// are expected ones. Returns true if err is nil.
+func AllMatchAny(err error, allowed ...error) bool {
+	all := true
+	ForEachUntil(err, func(err error) bool {
+		all = false
+		for _, target := range allowed {
+			if errors.Is(err, target) {
+				all = true
+				break
+			}
+		}
+		return all
+	})
+	return all
+}
+
+// FindAs returns the first error of type T found within the suberrors of err,
+// checking each suberror (and its wrapped errors) via errors.As in order.
+// It's a more convenient alternative to declaring a target and calling errors.As.
+func FindAs[T error](err error) (T, bool) {
+	var target T
+	var found bool
+	ForEachUntil(err, func(err error) bool {
+		found = errors.As(err, &target)
+		return !found
+	})
+	return target, found
+}
+
+// CountIs returns the number of suberrors of err that match target according
+// to errors.Is. For a non-multierror err, returns 1 or 0; for nil, returns 0.
+func CountIs(err, target error) int {
+	var n int
+	ForEach(err, func(err error) {
+		if errors.Is(err, target) {
+			n++
+		}
+	})
+	return n
+}
+
+// GroupByType buckets the suberrors of err by their dynamic types, preserving
+// their order within each bucket. Suberrors are not unwrapped, so an error
+// returned by fmt.Errorf is grouped as *fmt.wrapError regardless of what it
+// wraps. Returns an empty map if err is nil.
+func GroupByType(err error) map[reflect.Type][]error {
+	groups := make(map[reflect.Type][]error)
+	ForEach(err, func(err error) {
+		t := reflect.TypeOf(err)
+		groups[t] = append(groups[t], err)
+	})
+	return groups
+}
+
+// Equal reports whether a and b contain the same errors, regardless of order.
+// Combined errors should not be compared with ==, because the outcome depends
+// on how they were built: the result of Append for two single errors compares
+// by identity, while == panics for all other multierrors, including the ones
+// from Combine, Clone, Sort and Reverse (which also cannot be used as map
+// keys). Equal provides a well-defined equality instead.
+//
+// Two errors are equal if they have the same number of suberrors, and each
+// suberror of a can be paired with a distinct suberror of b such that
+// errors.Is holds in both directions. Two nil errors are equal.
+func Equal(a, b error) bool {
+	as, bs := All(a), All(b)
+	if len(as) != len(bs) {
+		return false
+	}
+	used := make([]bool, len(bs))
+	for _, ae := range as {
+		var found bool
+		for j, be := range bs {
+			if !used[j] && errors.Is(ae, be) && errors.Is(be, ae) {
+				used[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Severitier is implemented by errors that report their severity.
+type Severitier interface {
+	Severity() int
+}
+
+// MaxSeverity returns the highest severity among the suberrors of err that
+// implement Severitier (directly or via a wrapped error), or 0 if none do.
+func MaxSeverity(err error) int {
+	var highest int
+	var found bool
+	ForEach(err, func(err error) {
+		var s Severitier
+		if errors.As(err, &s) {
+			if v := s.Severity(); !found || v > highest {
+				highest = v
+				found = true
+			}
+		}
+	})
+	return highest
+}
+
+// HTTPStatus picks an HTTP response status code for err. Returns the highest
+// status reported by the suberrors implementing StatusCode() int or
+// HTTPStatus() int (directly or via a wrapped error), 500 if none do,
+// and 200 if err is nil.
+func HTTPStatus(err error) int {
+	if err == nil {
+		return 200
+	}
+	var highest int
+	ForEach(err, func(err error) {
+		var code int
+		var sc interface{ StatusCode() int }
+		var hs interface{ HTTPStatus() int }
+		if errors.As(err, &sc) {
+			code = sc.StatusCode()
+		} else if errors.As(err, &hs) {
+			code = hs.HTTPStatus()
+		}
+		if code > highest {
+			highest = code
+		}
+	})
+	if highest == 0 {
+		return 500
+	}
+	return highest
+}
+
+// ExitCode picks a process exit code for err. Returns the highest code
+// reported by the suberrors implementing ExitCode() int (directly or via
+// a wrapped error), 1 if none do, and 0 if err is nil.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var highest int
+	ForEach(err, func(err error) {
+		var ec interface{ ExitCode() int }
+		if errors.As(err, &ec) {
+			if code := ec.ExitCode(); code > highest {
+				highest = code
+			}
+		}
+	})
+	if highest == 0 {
+		return 1
+	}
+	return highest
+}
+
+// AllRetryable reports whether every suberror of err is temporary, i.e.
+// implements Temporary() bool (directly or via a wrapped error) returning true.
+// Suberrors without the method are treated as non-retryable. Returns false
+// if err is nil, as there's nothing to retry.
+func AllRetryable(err error) bool {
+	all := err != nil
+	ForEachUntil(err, func(err error) bool {
+		all = isTemporary(err)
+		return all
+	})
+	return all
+}
+
+// AnyRetryable reports whether any suberror of err is temporary, i.e.
+// implements Temporary() bool (directly or via a wrapped error) returning true.
+func AnyRetryable(err error) bool {
+	var found bool
+	ForEachUntil(err, func(err error) bool {
+		found = isTemporary(err)
+		return !found
+	})
+	return found
+}
+
+func isTemporary(err error) bool {
+	var t interface{ Temporary() bool }
+	return errors.As(err, &t) && t.Temporary()
+}
+
+// Stats returns the number of suberrors of err, and their numbers broken down
+// by dynamic type name, as reported by reflect.TypeOf(sub).String().
+// Returns (0, nil) if err is nil.
+func Stats(err error) (total int, byType map[string]int) {
+	ForEach(err, func(err error) {
+		if byType == nil {
+			byType = make(map[string]int)
+		}
+		byType[reflect.TypeOf(err).String()]++
+		total++
+	})
+	return total, byType
+}
+
+// Diff compares the suberrors of actual and expected according to errors.Is,
+// returning the expected suberrors that no actual suberror matches, and
+// the actual suberrors that match none of the expected ones. Useful for
+// readable test failures.
+func Diff(actual, expected error) (missing, extra []error) {
+	actuals, expecteds := All(actual), All(expected)
+	for _, e := range expecteds {
+		if !Contains(actual, e) {
+			missing = append(missing, e)
+		}
+	}
+	for _, a := range actuals {
+		var found bool
+		for _, e := range expecteds {
+			if errors.Is(a, e) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			extra = append(extra, a)
+		}
+	}
+	return missing, extra
+}
+
+// Reduce folds the suberrors of err into a single value, calling f for each
+// of them in order, starting with init. Returns init if err is nil.
+func Reduce[T any](err error, init T, f func(acc T, err error) T) T {
+	acc := init
+	ForEach(err, func(err error) {
+		acc = f(acc, err)
+	})
+	return acc
+}
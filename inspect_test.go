@@ -0,0 +1,240 @@
+package multierr_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/andreyvit/multierr"
+)
+
+func ExampleContains() {
+	err := multierr.Append(oops, fmt.Errorf("wrapped: %w", whoops))
+
+	fmt.Println(multierr.Contains(err, oops))
+	fmt.Println(multierr.Contains(err, whoops))
+	fmt.Println(multierr.Contains(err, whoopsie))
+	fmt.Println(multierr.Contains(whoops, whoops))
+	fmt.Println(multierr.Contains(nil, whoops))
+
+	// Output: true
+	// true
+	// false
+	// true
+	// false
+}
+
+func ExampleGroupByType() {
+	err := multierr.Combine(oops, &os.PathError{Op: "open", Path: "a", Err: os.ErrNotExist}, whoops, &os.PathError{Op: "open", Path: "b", Err: os.ErrPermission})
+	groups := multierr.GroupByType(err)
+
+	fmt.Println(len(groups))
+	fmt.Println(groups[reflect.TypeOf(oops)])
+	fmt.Println(groups[reflect.TypeOf(&os.PathError{})])
+	fmt.Println(len(multierr.GroupByType(nil)))
+
+	// Output: 2
+	// [oops whoops]
+	// [open a: file does not exist open b: permission denied]
+	// 0
+}
+
+func ExampleCountIs() {
+	err := multierr.Combine(os.ErrDeadlineExceeded, oops, fmt.Errorf("read: %w", os.ErrDeadlineExceeded))
+
+	fmt.Println(multierr.CountIs(err, os.ErrDeadlineExceeded))
+	fmt.Println(multierr.CountIs(err, oops))
+	fmt.Println(multierr.CountIs(err, whoops))
+	fmt.Println(multierr.CountIs(oops, oops))
+	fmt.Println(multierr.CountIs(nil, oops))
+
+	// Output: 2
+	// 1
+	// 0
+	// 1
+	// 0
+}
+
+func ExampleEqual() {
+	a := multierr.Combine(oops, whoops, whoopsie)
+	b := multierr.Combine(whoopsie, oops, whoops)
+
+	fmt.Println(multierr.Equal(a, b), multierr.Equal(b, a))
+	fmt.Println(multierr.Equal(a, multierr.Combine(oops, whoops)))
+	fmt.Println(multierr.Equal(multierr.Append(oops, oops), multierr.Append(oops, whoops)))
+	fmt.Println(multierr.Equal(oops, oops), multierr.Equal(nil, nil), multierr.Equal(oops, nil))
+
+	// Output: true true
+	// false
+	// false
+	// true true false
+}
+
+func ExampleFind() {
+	err := multierr.Combine(oops, whoops, whoopsie)
+	startsWithW := func(err error) bool {
+		return strings.HasPrefix(err.Error(), "w")
+	}
+
+	fmt.Println(multierr.Find(err, startsWithW))
+	fmt.Println(multierr.Find(err, func(error) bool { return false }))
+	fmt.Println(multierr.Find(oops, startsWithW))
+
+	// Output: whoops
+	// <nil>
+	// <nil>
+}
+
+func ExampleFindAs() {
+	err := multierr.Combine(oops, fmt.Errorf("load: %w", &os.PathError{Op: "open", Path: "a", Err: os.ErrNotExist}), whoops)
+
+	pe, ok := multierr.FindAs[*os.PathError](err)
+	fmt.Println(ok, pe.Path)
+
+	_, ok = multierr.FindAs[*os.LinkError](err)
+	fmt.Println(ok)
+
+	// Output: true a
+	// false
+}
+
+type severeError struct {
+	msg      string
+	severity int
+}
+
+func (e *severeError) Error() string { return e.msg }
+func (e *severeError) Severity() int { return e.severity }
+
+func ExampleMaxSeverity() {
+	err := multierr.Combine(
+		&severeError{"disk almost full", 2},
+		oops,
+		fmt.Errorf("sync: %w", &severeError{"replica down", 5}),
+		&severeError{"slow response", 1},
+	)
+	fmt.Println(multierr.MaxSeverity(err))
+	fmt.Println(multierr.MaxSeverity(oops))
+	fmt.Println(multierr.MaxSeverity(nil))
+
+	// Output: 5
+	// 0
+	// 0
+}
+
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string   { return http.StatusText(e.code) }
+func (e *statusError) StatusCode() int { return e.code }
+
+func ExampleHTTPStatus() {
+	err := multierr.Combine(&statusError{http.StatusNotFound}, oops, &statusError{http.StatusServiceUnavailable})
+	fmt.Println(multierr.HTTPStatus(err))
+	fmt.Println(multierr.HTTPStatus(oops))
+	fmt.Println(multierr.HTTPStatus(nil))
+
+	// Output: 503
+	// 500
+	// 200
+}
+
+type temporaryError struct{}
+
+func (temporaryError) Error() string   { return "try again" }
+func (temporaryError) Temporary() bool { return true }
+
+func ExampleAllRetryable() {
+	mixed := multierr.Combine(temporaryError{}, oops)
+	fmt.Println(multierr.AllRetryable(mixed), multierr.AnyRetryable(mixed))
+
+	temporary := multierr.Combine(temporaryError{}, fmt.Errorf("read: %w", temporaryError{}))
+	fmt.Println(multierr.AllRetryable(temporary), multierr.AnyRetryable(temporary))
+
+	fmt.Println(multierr.AllRetryable(oops), multierr.AnyRetryable(oops))
+	fmt.Println(multierr.AllRetryable(nil), multierr.AnyRetryable(nil))
+
+	// Output: false true
+	// true true
+	// false false
+	// false false
+}
+
+func ExampleStats() {
+	err := multierr.Combine(oops, &os.PathError{Op: "open", Path: "a", Err: os.ErrNotExist}, whoops)
+	fmt.Println(multierr.Stats(err))
+	fmt.Println(multierr.Stats(oops))
+	fmt.Println(multierr.Stats(nil))
+
+	// Output: 3 map[*errors.errorString:2 *fs.PathError:1]
+	// 1 map[*errors.errorString:1]
+	// 0 map[]
+}
+
+func ExampleAllMatchAny() {
+	expected := multierr.Combine(io.EOF, fmt.Errorf("read: %w", os.ErrDeadlineExceeded), io.EOF)
+	fmt.Println(multierr.AllMatchAny(expected, io.EOF, os.ErrDeadlineExceeded))
+
+	unexpected := multierr.Append(expected, oops)
+	fmt.Println(multierr.AllMatchAny(unexpected, io.EOF, os.ErrDeadlineExceeded))
+
+	fmt.Println(multierr.AllMatchAny(nil, io.EOF))
+
+	// Output: true
+	// false
+	// true
+}
+
+func ExampleDiff() {
+	actual := multierr.Combine(oops, fmt.Errorf("read: %w", io.EOF), whoopsie)
+	expected := multierr.Combine(io.EOF, oops, whoops)
+	fmt.Println(multierr.Diff(actual, expected))
+
+	fmt.Println(multierr.Diff(oops, whoops))
+	fmt.Println(multierr.Diff(actual, actual))
+
+	// Output: [whoops] [whoopsie]
+	// [whoops] [oops]
+	// [] []
+}
+
+func ExampleReduce() {
+	err := multierr.Combine(&severeError{"disk almost full", 2}, oops, &severeError{"replica down", 5})
+
+	count := multierr.Reduce(err, 0, func(n int, err error) int {
+		return n + 1
+	})
+	highest := multierr.Reduce(err, 0, func(highest int, err error) int {
+		if s, ok := err.(multierr.Severitier); ok && s.Severity() > highest {
+			return s.Severity()
+		}
+		return highest
+	})
+	fmt.Println(count, highest)
+	fmt.Println(multierr.Reduce(nil, 42, func(int, error) int { return 0 }))
+
+	// Output: 3 5
+	// 42
+}
+
+type exitError struct {
+	code int
+}
+
+func (e *exitError) Error() string { return fmt.Sprintf("exit status %d", e.code) }
+func (e *exitError) ExitCode() int { return e.code }
+
+func ExampleExitCode() {
+	err := multierr.Combine(&exitError{2}, oops, fmt.Errorf("lint: %w", &exitError{3}))
+	fmt.Println(multierr.ExitCode(err))
+	fmt.Println(multierr.ExitCode(oops))
+	fmt.Println(multierr.ExitCode(nil))
+
+	// Output: 3
+	// 1
+	// 0
+}
@@ -0,0 +1,57 @@
+package multierr
+
+// AppendWithLabel appends err to dest, attaching the given label, which is
+// available via the Labeler interface and printed by DefaultFormatMessage in
+// place of the error's number. If err is a multierror, each of its suberrors
+// is labeled. A nil err is a no-op.
+//
+// The label does not change the error's message, and the original error
+// remains reachable via errors.Is and errors.As.
+func AppendWithLabel(dest error, label string, err error) error {
+	return Append(dest, Map(err, func(err error) error {
+		return &labeled{label, err}
+	}))
+}
+
+type labeled struct {
+	label string
+	err   error
+}
+
+func (e *labeled) Error() string {
+	return e.err.Error()
+}
+
+func (e *labeled) Label() string {
+	return e.label
+}
+
+func (e *labeled) Unwrap() error {
+	return e.err
+}
+
+// Entry describes a single suberror, as returned by Entries.
+type Entry struct {
+	// Index is the zero-based position of the suberror.
+	Index int
+
+	// Label is the label of the suberror (see Labeler), or an empty string.
+	Label string
+
+	// Err is the suberror itself.
+	Err error
+}
+
+// Entries returns the suberrors of err along with their indices and labels,
+// e.g. for building a report table. Returns nil if err is nil.
+func Entries(err error) []Entry {
+	var entries []Entry
+	EachIndexed(err, func(i int, err error) {
+		entry := Entry{Index: i, Err: err}
+		if l, ok := findLabeler(nil, err); ok {
+			entry.Label = l.Label()
+		}
+		entries = append(entries, entry)
+	})
+	return entries
+}
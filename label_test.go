@@ -0,0 +1,47 @@
+package multierr_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/andreyvit/multierr"
+)
+
+func ExampleAppendWithLabel() {
+	var err error
+	err = multierr.AppendWithLabel(err, "email", errors.New("is required"))
+	err = multierr.AppendWithLabel(err, "name", nil)
+	err = multierr.AppendWithLabel(err, "age", oops)
+	fmt.Println(err)
+
+	for _, sub := range multierr.All(err) {
+		fmt.Println(sub.(multierr.Labeler).Label())
+	}
+	fmt.Println(errors.Is(err, oops))
+
+	// Output: 2 errors occurred:
+	// (email) is required
+	// (age) oops
+	// email
+	// age
+	// true
+}
+
+func ExampleEntries() {
+	var err error
+	err = multierr.AppendWithLabel(err, "email", errors.New("is required"))
+	err = multierr.Append(err, oops)
+	err = multierr.AppendWithLabel(err, "age", whoops)
+
+	for _, e := range multierr.Entries(err) {
+		fmt.Printf("%d %q %v\n", e.Index, e.Label, e.Err)
+	}
+	fmt.Println(multierr.Entries(nil) == nil)
+	fmt.Printf("%q\n", multierr.Entries(multierr.WithPrefix(err, "signup"))[0].Label)
+
+	// Output: 0 "email" is required
+	// 1 "" oops
+	// 2 "age" whoops
+	// true
+	// "email"
+}
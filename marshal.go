@@ -0,0 +1,57 @@
+package multierr
+
+import (
+	"encoding/json"
+	"log/slog"
+	"strconv"
+)
+
+// MarshalJSON encodes a multierror as a JSON array of suberror messages,
+// e.g. ["oops","whoops"].
+//
+// Note that Append and friends return single errors as is, and those are
+// marshaled according to their own type (often as {}). To get the same array
+// representation regardless of the number of errors, marshal Messages(err).
+func (m multi) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Messages(m))
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the same message
+// as Error.
+func (m multi) MarshalText() ([]byte, error) {
+	return []byte(m.Error()), nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v3 (and
+// similar libraries) without depending on any of them, encoding a multierror
+// as a list of suberror messages.
+func (m multi) MarshalYAML() (any, error) {
+	return Messages(m), nil
+}
+
+// Messages returns the messages of all suberrors within err.
+// If err is not a multierror type, returns []string{err.Error()}.
+// If err is nil, returns nil.
+func Messages(err error) []string {
+	var msgs []string
+	ForEach(err, func(err error) {
+		msgs = append(msgs, err.Error())
+	})
+	return msgs
+}
+
+// LogValue implements slog.LogValuer, logging a multierror as a group with
+// one attribute per suberror, keyed err0, err1 and so on. Each attribute holds
+// the suberror's message, or its LogValue if the suberror is a slog.LogValuer.
+func (m multi) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, len(m))
+	for i, err := range m {
+		key := "err" + strconv.Itoa(i)
+		if lv, ok := err.(slog.LogValuer); ok {
+			attrs = append(attrs, slog.Attr{Key: key, Value: lv.LogValue()})
+		} else {
+			attrs = append(attrs, slog.String(key, err.Error()))
+		}
+	}
+	return slog.GroupValue(attrs...)
+}
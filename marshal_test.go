@@ -0,0 +1,102 @@
+package multierr_test
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/andreyvit/multierr"
+)
+
+func ExampleAppend_json() {
+	err := multierr.Append(oops, errors.New("bad \"quote\"\n\tand <tag>"))
+	b, _ := json.Marshal(err)
+	fmt.Println(string(b))
+
+	b, _ = json.Marshal(multierr.Messages(oops))
+	fmt.Println(string(b))
+
+	b, _ = json.Marshal(multierr.Messages(nil))
+	fmt.Println(string(b))
+
+	// Output: ["oops","bad \"quote\"\n\tand \u003ctag\u003e"]
+	// ["oops"]
+	// null
+}
+
+type capturingHandler struct {
+	records []slog.Record
+}
+
+func (h *capturingHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *capturingHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *capturingHandler) WithGroup(string) slog.Handler            { return h }
+
+func (h *capturingHandler) Handle(_ context.Context, r slog.Record) error {
+	h.records = append(h.records, r)
+	return nil
+}
+
+type loggableError struct{}
+
+func (loggableError) Error() string { return "loggable" }
+
+func (loggableError) LogValue() slog.Value {
+	return slog.GroupValue(slog.Int("code", 42))
+}
+
+func ExampleAppend_slog() {
+	h := &capturingHandler{}
+	logger := slog.New(h)
+
+	err := multierr.Combine(oops, loggableError{}, whoops)
+	logger.Error("failed", slog.Any("err", err))
+
+	var print func(indent string, a slog.Attr)
+	print = func(indent string, a slog.Attr) {
+		v := a.Value.Resolve()
+		if v.Kind() == slog.KindGroup {
+			fmt.Printf("%s%s:\n", indent, a.Key)
+			for _, a := range v.Group() {
+				print(indent+"  ", a)
+			}
+		} else {
+			fmt.Printf("%s%s: %v\n", indent, a.Key, v)
+		}
+	}
+	h.records[0].Attrs(func(a slog.Attr) bool {
+		print("", a)
+		return true
+	})
+
+	// Output: err:
+	//   err0: oops
+	//   err1:
+	//     code: 42
+	//   err2: whoops
+}
+
+func ExampleAppend_text() {
+	err := multierr.Append(oops, whoops)
+
+	b, _ := err.(encoding.TextMarshaler).MarshalText()
+	fmt.Println(string(b) == err.Error())
+	fmt.Println(string(b))
+
+	// Output: true
+	// 2 errors occurred:
+	// (1) oops
+	// (2) whoops
+}
+
+func ExampleAppend_yaml() {
+	err := multierr.Append(oops, whoops)
+
+	v, _ := err.(interface{ MarshalYAML() (any, error) }).MarshalYAML()
+	fmt.Printf("%#v\n", v)
+
+	// Output: []string{"oops", "whoops"}
+}
@@ -22,23 +22,62 @@
 // a single error to return.
 //
 // The returned multierror type supports errors.Is and errors.As by delegating
-// to each of the suberrors it contains.
+// to each of the suberrors it contains. It also implements Unwrap() []error,
+// so Go 1.20+ tools that walk the error tree see every suberror. To get at the
+// suberrors generically, use errors.As with a Multierror target.
 package multierr
 
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 )
 
+// ErrMultiple matches any multierror returned by this package via errors.Is,
+// allowing to check whether an error combines multiple errors:
+//
+//   if errors.Is(err, multierr.ErrMultiple) { ... }
+//
+// Single errors do not match it (unless they wrap a multierror).
+var ErrMultiple = errors.New("multiple errors")
+
+// Multierror is implemented by the errors Append returns when combining
+// multiple errors. Use errors.As to obtain it:
+//
+//   var m multierr.Multierror
+//   if errors.As(err, &m) {
+//   	for _, sub := range m.Errors() { ... }
+//   }
+//
+// All is a convenience wrapper that also handles single errors.
+type Multierror interface {
+	error
+	Errorser
+}
+
+// Errorser is implemented by multierrors, allowing read-only access to their
+// suberrors via a type assertion, without the allocation All incurs:
+//
+//   if m, ok := err.(multierr.Errorser); ok {
+//   	for _, sub := range m.Errors() { ... }
+//   }
+type Errorser interface {
+	// Errors returns the suberrors. The returned slice is the multierror's
+	// backing storage and must not be modified.
+	Errors() []error
+}
+
 // multi is the type returned when Append needs to combine multiple errors;
-// it will always have at least 2 items.
+// it will always have at least 2 items, except when created by AsMulti.
+// Error still handles shorter ones gracefully, because panicking while printing
+// an error would be worse.
 type multi []error
 
 func (m multi) Error() string {
 	switch len(m) {
 	case 0:
-		panic("multierr.multi does not support zero errors")
+		return "(no errors)"
 	case 1:
 		return m[0].Error()
 	default:
@@ -46,6 +85,74 @@ func (m multi) Error() string {
 	}
 }
 
+// pair is a multi of exactly two errors, allocated together with its backing
+// array. Append returns it when combining two plain errors (by far the most
+// common case), taking one allocation instead of two. All methods are promoted
+// from multi, so it behaves the same via the error interfaces.
+//
+// This is a deliberate trade-off: unlike multi, it's visible as *multierr.pair
+// to %T and reflection, and being a pointer, it compares with == by identity,
+// whereas == panics for multi values. Equal is the supported way to compare.
+type pair struct {
+	multi
+	errs [2]error
+}
+
+func newPair(a, b error) *pair {
+	p := &pair{errs: [2]error{a, b}}
+	p.multi = p.errs[:]
+	return p
+}
+
+// toMulti returns err as a multi if it's one of our multierror types.
+func toMulti(err error) (multi, bool) {
+	switch e := err.(type) {
+	case multi:
+		return e, true
+	case *pair:
+		return e.multi, true
+	default:
+		return nil, false
+	}
+}
+
+// Format implements fmt.Formatter. All verbs, flags and widths apply to the
+// message returned by Error, as they would for a string, except that %+v
+// always uses the default format, printing each suberror with %+v, so that
+// suberrors carrying extra details (like stack traces) include them.
+//
+// For %v and %s, the precision limits the number of suberrors printed,
+// as with Truncate: %.3v prints the first 3 and an "and N more errors" line.
+// Truncated output always uses the default format, with the header still
+// counting all suberrors.
+func (m multi) Format(f fmt.State, verb rune) {
+	p, ok := f.Precision()
+	truncating := ok && p < len(m) && (verb == 'v' || verb == 's')
+	style := messageStyle{msg: error.Error}
+	if verb == 'v' && f.Flag('+') {
+		style.msg = func(err error) string {
+			return fmt.Sprintf("%+v", err)
+		}
+	} else if !truncating {
+		writeFormatted(f, verb, m.Error())
+		return
+	}
+
+	var buf strings.Builder
+	if truncating {
+		truncated := Truncate(m, p)
+		if tm, ok := toMulti(truncated); ok {
+			style.total = len(m)
+			writeMessage(&buf, tm, style)
+		} else {
+			buf.WriteString(truncated.Error())
+		}
+	} else {
+		writeMessage(&buf, m, style)
+	}
+	writeFormatted(f, verb, buf.String())
+}
+
 func (m multi) As(target interface{}) bool {
 	for _, err := range m {
 		if errors.As(err, target) {
@@ -55,7 +162,22 @@ func (m multi) As(target interface{}) bool {
 	return false
 }
 
+// Is reports whether any of the suberrors matches target via errors.Is.
+// Every multierror matches ErrMultiple. If target is itself a multierror,
+// Is uses subset semantics instead, and reports whether each of
+// the target's suberrors is matched by some suberror of m.
 func (m multi) Is(target error) bool {
+	if target == ErrMultiple {
+		return true
+	}
+	if tm, ok := toMulti(target); ok {
+		for _, t := range tm {
+			if !m.Is(t) {
+				return false
+			}
+		}
+		return true
+	}
 	for _, err := range m {
 		if errors.Is(err, target) {
 			return true
@@ -64,6 +186,17 @@ func (m multi) Is(target error) bool {
 	return false
 }
 
+// Errors implements Errorser.
+func (m multi) Errors() []error {
+	return []error(m)
+}
+
+// Unwrap returns the suberrors, following the Go 1.20 convention for errors
+// that wrap multiple errors. Is and As are kept for older Go versions.
+func (m multi) Unwrap() []error {
+	return []error(m)
+}
+
 // Append joins the given error values into a single error. If both are non-nil,
 // wraps them into a multierror type (which is a typed []error slice),
 // otherwise returns one of the arguments.
@@ -75,25 +208,300 @@ func (m multi) Is(target error) bool {
 //
 // Either (or both) of the arguments can be multierror types, which are properly
 // joined together. In this case, Append assumes that it's fine to modify
-// either of the arguments.
+// either of the arguments: appending to a multierror may reuse its backing
+// array, so the same multierror must not be appended to twice. Use AppendNew
+// when the destination is shared. This includes foreign multierrors, i.e. errors
+// returned by errors.Join and errors implementing WrappedErrors() []error
+// (like github.com/hashicorp/go-multierror), whose suberrors are merged rather
+// than nested. Other errors implementing Unwrap() []error, like the ones
+// returned by fmt.Errorf with multiple %w verbs, carry a message of their own,
+// and are kept as single errors.
 func Append(dest error, err error) error {
 	if err == nil {
 		return dest
 	} else if dest == nil {
 		return err
-	} else if md, ok := dest.(multi); ok {
-		if ms, ok := err.(multi); ok {
-			return append(md, ms...)
-		} else {
-			return append(md, err)
+	} else if md, ok := toMulti(dest); ok {
+		return collapse(appendUnwrapped(md, err))
+	} else if !isMultierror(dest) && !isMultierror(err) {
+		return newPair(dest, err)
+	} else {
+		return collapse(appendUnwrapped(appendUnwrapped(make([]error, 0, 2), dest), err))
+	}
+}
+
+// isMultierror reports whether err is a multierror, either our own or one of
+// the foreign types Append knows how to merge.
+func isMultierror(err error) bool {
+	_, ok := unwrapMulti(err)
+	return ok
+}
+
+// CollectFunc calls f for each of items in order, and combines the returned
+// errors like Combine does. Like AppendAll, it allocates the combined slice
+// once, sized for the remaining items when the first error occurs, unless
+// f returns multierrors.
+//
+//   err := multierr.CollectFunc(results, func(r Result) error { return r.Err })
+func CollectFunc[T any](items []T, f func(item T) error) error {
+	var errs []error
+	for i, item := range items {
+		err := f(item)
+		if err == nil {
+			continue
+		}
+		if errs == nil {
+			errs = make([]error, 0, countUnwrapped(err)+len(items)-i-1)
+		}
+		errs = appendUnwrapped(errs, err)
+	}
+	return collapse(errs)
+}
+
+// AppendNew is like Append, but never modifies either of the arguments,
+// always allocating a new multierror when combining errors. Use it when dest
+// is shared, e.g. when producing several different errors from a common part.
+func AppendNew(dest error, err error) error {
+	return AppendAll(dest, err)
+}
+
+// appendUnwrapped appends err to errs, adding each suberror individually
+// if err is a multierror.
+func appendUnwrapped(errs []error, err error) []error {
+	if sub, ok := unwrapMulti(err); ok {
+		for _, err := range sub {
+			if err != nil {
+				errs = append(errs, err)
+			}
 		}
+		return errs
 	} else {
-		if ms, ok := err.(multi); ok {
-			return append(multi{dest}, ms...)
-		} else {
-			return multi{dest, err}
+		return append(errs, err)
+	}
+}
+
+// countUnwrapped returns the number of errors appendUnwrapped would add for err.
+func countUnwrapped(err error) int {
+	if sub, ok := unwrapMulti(err); ok {
+		return len(sub)
+	} else if err != nil {
+		return 1
+	} else {
+		return 0
+	}
+}
+
+// joinErrorType is the type of errors returned by errors.Join.
+var joinErrorType = reflect.TypeOf(errors.Join(errors.New("")))
+
+// unwrapMulti returns the suberrors of err if it's a multierror, either our own
+// or one of the foreign types Append knows how to merge. A foreign multierror
+// without any non-nil suberrors is not considered a multierror, so that it is
+// kept as a single error rather than disappearing.
+func unwrapMulti(err error) ([]error, bool) {
+	if m, ok := toMulti(err); ok {
+		return m, true
+	}
+	var sub []error
+	switch e := err.(type) {
+	case interface{ WrappedErrors() []error }:
+		sub = e.WrappedErrors()
+	case interface{ Unwrap() []error }:
+		if reflect.TypeOf(err) != joinErrorType {
+			return nil, false
+		}
+		sub = e.Unwrap()
+	default:
+		return nil, false
+	}
+	for _, err := range sub {
+		if err != nil {
+			return sub, true
+		}
+	}
+	return nil, false
+}
+
+// MustAppend is like Append, but panics if both dest and err are nil, for code
+// paths where a failure is known to have occurred and must be recorded.
+func MustAppend(dest error, err error) error {
+	if dest == nil && err == nil {
+		panic("multierr.MustAppend: no error to record")
+	}
+	return Append(dest, err)
+}
+
+// AppendIf appends err to dest if cond is true, and returns dest unchanged
+// otherwise.
+//
+//   err = multierr.AppendIf(err, name == "", errNameRequired)
+func AppendIf(dest error, cond bool, err error) error {
+	if !cond {
+		return dest
+	}
+	return Append(dest, err)
+}
+
+// Appendf appends an error built via fmt.Errorf(format, args...) to dest,
+// so %w verbs keep the wrapped errors reachable via errors.Is and errors.As.
+// An empty format is a no-op.
+func Appendf(dest error, format string, args ...interface{}) error {
+	if format == "" {
+		return dest
+	}
+	return Append(dest, fmt.Errorf(format, args...))
+}
+
+// Prepend is like Append, but puts err before the errors already in dest.
+//
+//   Prepend(nil, someErr) == someErr
+//   Prepend(someErr, nil) == someErr
+//   Prepend(someErr, anotherErr) == []error{anotherErr, someErr}
+//
+// Same as with Append, multierror arguments are joined together, keeping the
+// order within each of them, and either of the arguments may be modified.
+func Prepend(dest error, err error) error {
+	return Append(err, dest)
+}
+
+// Combine joins all non-nil errors into a single error, following the same
+// rules as Append: returns nil if there are no non-nil errors, returns the
+// error itself if there's only one, and otherwise returns a multierror
+// containing all of them. Multierror arguments (including the foreign ones
+// recognized by Append) are flattened rather than nested.
+//
+// Unlike Append, Combine never modifies its arguments.
+func Combine(errs ...error) error {
+	var n int
+	for _, err := range errs {
+		n += countUnwrapped(err)
+	}
+	if n == 0 {
+		return nil
+	}
+
+	result := make([]error, 0, n)
+	for _, err := range errs {
+		if err != nil {
+			result = appendUnwrapped(result, err)
 		}
 	}
+	return collapse(result)
+}
+
+// Join is the same as Combine, named after errors.Join for familiarity.
+// Unlike errors.Join, it flattens multierror arguments instead of nesting them,
+// and returns a single non-nil error as is instead of wrapping it.
+func Join(errs ...error) error {
+	return Combine(errs...)
+}
+
+// Merge is like Combine, but skips errors that are already present, keeping
+// the first occurrence of each. Errors are compared by identity (==), not by
+// message; see Deduplicate for the latter.
+func Merge(errs ...error) error {
+	var result []error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		for _, err := range appendUnwrapped(nil, err) {
+			if !containsIdentical(result, err) {
+				result = append(result, err)
+			}
+		}
+	}
+	return collapse(result)
+}
+
+// containsIdentical reports whether errs contains err itself, comparing errors
+// with == when their type allows it, like errors.Is does.
+func containsIdentical(errs []error, err error) bool {
+	if !reflect.TypeOf(err).Comparable() {
+		return false
+	}
+	for _, e := range errs {
+		if e == err {
+			return true
+		}
+	}
+	return false
+}
+
+// Coalesce returns the first non-nil error among errs, or nil if there is none.
+// Unlike Combine, it ignores all errors after the first one.
+func Coalesce(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AppendAll appends all non-nil errs to dest. The result is the same as calling
+// Append for each of errs in turn, but the combined slice is allocated once.
+//
+// Unlike Append, AppendAll never modifies its arguments.
+func AppendAll(dest error, errs ...error) error {
+	var n int
+	for _, err := range errs {
+		n += countUnwrapped(err)
+	}
+	if n == 0 {
+		return dest
+	}
+
+	result := make([]error, 0, countUnwrapped(dest)+n)
+	if dest != nil {
+		result = appendUnwrapped(result, dest)
+	}
+	for _, err := range errs {
+		if err != nil {
+			result = appendUnwrapped(result, err)
+		}
+	}
+	return collapse(result)
+}
+
+// AppendInto appends err to the error dest points to, as in
+// *dest = Append(*dest, err). A nil err is a no-op; dest must not be nil.
+//
+//   defer func() {
+//   	multierr.AppendInto(&err, d.Close())
+//   }()
+//
+// Note that the arguments of a deferred call are evaluated immediately, so
+// defer multierr.AppendInto(&err, d.Close()) would close d right away.
+func AppendInto(dest *error, err error) {
+	if dest == nil {
+		panic("multierr.AppendInto: nil dest")
+	}
+	if err == nil {
+		return
+	}
+	*dest = Append(*dest, err)
+}
+
+// Recover converts a panic into an error appended to the error dest points to.
+// It must be deferred directly, as recover only works when called by
+// a deferred function:
+//
+//   defer multierr.Recover(&err)
+//
+// If the recovered value is an error, it is appended as is, keeping any
+// information (like a stack trace) it carries; other values are formatted
+// as "panic: <value>". Does nothing if there is no panic.
+func Recover(dest *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if err, ok := r.(error); ok {
+		AppendInto(dest, err)
+	} else {
+		AppendInto(dest, fmt.Errorf("panic: %v", r))
+	}
 }
 
 // ForEach calls f with each suberror in the given error.
@@ -102,7 +510,7 @@ func Append(dest error, err error) error {
 func ForEach(err error, f func(err error)) {
 	if err == nil {
 		// nop
-	} else if m, ok := err.(multi); ok {
+	} else if m, ok := toMulti(err); ok {
 		for _, err := range m {
 			f(err)
 		}
@@ -111,20 +519,171 @@ func ForEach(err error, f func(err error)) {
 	}
 }
 
+// EachIndexed calls f with each suberror in the given error and its index.
+// If err is not a multierror type, calls f(0, err).
+// If err is nil, does not call f.
+func EachIndexed(err error, f func(i int, err error)) {
+	if err == nil {
+		// nop
+	} else if m, ok := toMulti(err); ok {
+		for i, err := range m {
+			f(i, err)
+		}
+	} else {
+		f(0, err)
+	}
+}
+
+// ForEachUntil calls f with each suberror in the given error, stopping
+// as soon as f returns false.
+// If err is not a multierror type, calls f(err).
+// If err is nil, does not call f.
+func ForEachUntil(err error, f func(err error) bool) {
+	if err == nil {
+		// nop
+	} else if m, ok := toMulti(err); ok {
+		for _, err := range m {
+			if !f(err) {
+				break
+			}
+		}
+	} else {
+		f(err)
+	}
+}
+
+// ForEachLeaf calls f with the leaf errors within each suberror of err, i.e.
+// the innermost errors found by following Unwrap() error and Unwrap() []error.
+// A suberror that doesn't wrap anything is a leaf itself.
+// If err is nil, does not call f.
+//
+// Pointer errors that wrap one of their own ancestors are treated as leaves,
+// so cyclic chains do not cause infinite recursion.
+func ForEachLeaf(err error, f func(err error)) {
+	var path []uintptr
+	var visit func(err error)
+	visit = func(err error) {
+		if v := reflect.ValueOf(err); v.Kind() == reflect.Pointer {
+			p := v.Pointer()
+			for _, q := range path {
+				if p == q {
+					f(err)
+					return
+				}
+			}
+			path = append(path, p)
+			defer func() { path = path[:len(path)-1] }()
+		}
+
+		switch e := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, err := range e.Unwrap() {
+				if err != nil {
+					visit(err)
+				}
+			}
+		case interface{ Unwrap() error }:
+			if inner := e.Unwrap(); inner != nil {
+				visit(inner)
+			} else {
+				f(err)
+			}
+		default:
+			f(err)
+		}
+	}
+	ForEach(err, visit)
+}
+
 // Returns the number of suberrors within err.
 // If err is not a multierror type, returns 1.
 // If err is nil, returns 0.
 func Len(err error) int {
 	if err == nil {
 		return 0
-	} else if m, ok := err.(multi); ok {
+	} else if m, ok := toMulti(err); ok {
 		return len(m)
 	} else {
 		return 1
 	}
 }
 
-// Returns all suberrors within err.
+// AsMulti returns err as a multierror even if it's a single error, so that
+// code which handles multierrors specially (e.g. via Errorser or
+// Unwrap() []error) can treat all errors uniformly. This intentionally breaks
+// the usual rule that single errors are returned as is; the result prints the
+// same as err itself. Multierrors and nil are returned as is.
+func AsMulti(err error) error {
+	if err == nil {
+		return nil
+	} else if _, ok := toMulti(err); ok {
+		return err
+	} else {
+		return multi{err}
+	}
+}
+
+// IsEmpty reports whether err contains no errors, i.e. whether it's nil.
+// It's the same as Len(err) == 0.
+func IsEmpty(err error) bool {
+	return Len(err) == 0
+}
+
+// HasErrors reports whether err contains any errors, i.e. whether it's non-nil.
+// It's the same as Len(err) > 0.
+func HasErrors(err error) bool {
+	return Len(err) > 0
+}
+
+// First returns the first suberror within err.
+// If err is not a multierror type, returns err.
+// If err is nil, returns nil.
+func First(err error) error {
+	if m, ok := toMulti(err); ok {
+		return m[0]
+	} else {
+		return err
+	}
+}
+
+// Last returns the last suberror within err.
+// If err is not a multierror type, returns err.
+// If err is nil, returns nil.
+func Last(err error) error {
+	if m, ok := toMulti(err); ok {
+		return m[len(m)-1]
+	} else {
+		return err
+	}
+}
+
+// HeadTail splits err into its first suberror and the remaining ones, combined
+// into a single error (or nil if there are none).
+// If err is not a multierror type, returns (err, nil).
+// If err is nil, returns (nil, nil).
+func HeadTail(err error) (head error, tail error) {
+	if m, ok := toMulti(err); ok {
+		return m[0], collapse(m[1:len(m):len(m)])
+	} else {
+		return err, nil
+	}
+}
+
+// At returns the i-th suberror within err, for 0 <= i < Len(err).
+// If err is not a multierror type, the only valid index is 0, returning err.
+// If err is nil, there are no valid indices.
+// Like slice indexing, panics if i is out of range.
+func At(err error, i int) error {
+	if m, ok := toMulti(err); ok {
+		return m[i]
+	} else if err != nil && i == 0 {
+		return err
+	} else {
+		panic(fmt.Sprintf("multierr.At: index %d out of range [0:%d]", i, Len(err)))
+	}
+}
+
+// Returns all suberrors within err as a newly allocated slice.
 // If err is not a multierror type, returns []error{err}.
 // If err is nil, returns nil.
 func All(err error) []error {
@@ -135,21 +694,38 @@ func All(err error) []error {
 	return errs
 }
 
-// FormatMessage is a function used to format a string with multiple error messages.
-// You can replace it if your project calls for a different format.
-// Note that this is a global setting and should be left to the end user to decide.
-var FormatMessage func(errs []error) string = DefaultFormatMessage
+// AppendToSlice appends all suberrors within err to dst and returns
+// the extended slice, allowing to reuse a pre-sized or pooled slice.
+// If err is not a multierror type, appends err.
+// If err is nil, returns dst unchanged.
+func AppendToSlice(err error, dst []error) []error {
+	if err == nil {
+		return dst
+	} else if m, ok := toMulti(err); ok {
+		return append(dst, m...)
+	} else {
+		return append(dst, err)
+	}
+}
 
-// DefaultFormatMessage performs the default formatting of multiple error messages.
-func DefaultFormatMessage(errs []error) string {
-	var buf strings.Builder
-	fmt.Fprintf(&buf, "%d errors occurred:\n", len(errs))
-	for i, err := range errs {
-		if i > 0 {
-			buf.WriteByte('\n')
-		}
-		s := err.Error()
-		fmt.Fprintf(&buf, "(%d) %s", i+1, strings.ReplaceAll(s, "\n", "\n\t"))
+// FromSlice converts a slice of errors into a single error, skipping nil
+// entries. This is the inverse of All: returns nil for an empty or all-nil
+// slice, the error itself if there's only one, and a multierror otherwise.
+//
+// The slice is not retained, so the caller is free to reuse it.
+func FromSlice(errs []error) error {
+	return Combine(errs...)
+}
+
+// collapse returns nil, the only error or a multierror wrapping errs,
+// depending on len(errs). The errs slice must not contain nils and is retained.
+func collapse(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return multi(errs)
 	}
-	return buf.String()
 }
@@ -3,6 +3,9 @@ package multierr_test
 import (
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"testing"
 
 	"github.com/andreyvit/multierr"
 )
@@ -57,3 +60,627 @@ func Example_is() {
 	// false
 	// true
 }
+
+func Example_unwrap() {
+	var err error
+	err = multierr.Append(err, oops)
+	err = multierr.Append(err, fmt.Errorf("wrapped: %w", whoops))
+
+	var walk func(err error, depth int)
+	walk = func(err error, depth int) {
+		fmt.Printf("%*s%v\n", 2*depth, "", err)
+		switch e := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, sub := range e.Unwrap() {
+				walk(sub, depth+1)
+			}
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap(), depth+1)
+		}
+	}
+	for _, sub := range err.(interface{ Unwrap() []error }).Unwrap() {
+		walk(sub, 0)
+	}
+	fmt.Println(errors.Is(err, whoops))
+
+	// Output: oops
+	// wrapped: whoops
+	//   whoops
+	// true
+}
+
+func ExampleAppendInto() {
+	f := func() (err error) {
+		defer func() {
+			multierr.AppendInto(&err, whoopsie)
+		}()
+		defer func() {
+			multierr.AppendInto(&err, nil)
+		}()
+		defer func() {
+			multierr.AppendInto(&err, whoops)
+		}()
+		return nil
+	}
+
+	fmt.Println(f())
+	// Output: 2 errors occurred:
+	// (1) whoops
+	// (2) whoopsie
+}
+
+func ExampleCombine() {
+	fmt.Println(multierr.Combine())
+	fmt.Println(multierr.Combine(nil, nil))
+	fmt.Println(multierr.Combine(nil, oops, nil) == oops)
+	fmt.Println(multierr.Combine(oops, nil, multierr.Append(whoops, whoopsie)))
+
+	// Output: <nil>
+	// <nil>
+	// true
+	// 3 errors occurred:
+	// (1) oops
+	// (2) whoops
+	// (3) whoopsie
+}
+
+func ExampleFromSlice() {
+	fmt.Println(multierr.FromSlice(nil))
+	fmt.Println(multierr.FromSlice([]error{nil, nil}))
+	fmt.Println(multierr.FromSlice([]error{nil, oops, nil}) == oops)
+
+	errs := multierr.All(multierr.FromSlice([]error{oops, nil, whoops, nil, whoopsie}))
+	fmt.Println(errs)
+
+	// Output: <nil>
+	// <nil>
+	// true
+	// [oops whoops whoopsie]
+}
+
+func ExamplePrepend() {
+	fmt.Println(multierr.All(multierr.Prepend(multierr.Append(nil, oops), whoops)))
+
+	a := multierr.Append(oops, whoops)
+	b := multierr.Append(whoopsie, io.EOF)
+	fmt.Println(multierr.All(multierr.Prepend(a, b)))
+
+	// Output: [whoops oops]
+	// [whoopsie EOF oops whoops]
+}
+
+type detailedError struct {
+	msg    string
+	detail string
+}
+
+func (e *detailedError) Error() string {
+	return e.msg
+}
+
+func (e *detailedError) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		fmt.Fprintf(f, "%s\n%s", e.msg, e.detail)
+		return
+	}
+	io.WriteString(f, e.msg)
+}
+
+func ExampleAppend_format() {
+	err := multierr.Append(oops, &detailedError{"whoops", "at main.go:42"})
+
+	fmt.Printf("%v\n", err)
+	fmt.Printf("%+v\n", err)
+	fmt.Printf("%q\n", err)
+
+	// Output: 2 errors occurred:
+	// (1) oops
+	// (2) whoops
+	// 2 errors occurred:
+	// (1) oops
+	// (2) whoops
+	// 	at main.go:42
+	// "2 errors occurred:\n(1) oops\n(2) whoops"
+}
+
+func ExampleAppendf() {
+	var err error
+	err = multierr.Appendf(err, "read %s: %w", "config.json", oops)
+	err = multierr.Appendf(err, "")
+	err = multierr.Appendf(err, "read %s: %w", "data.json", whoops)
+
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, oops), errors.Is(err, whoops), errors.Is(err, whoopsie))
+
+	// Output: 2 errors occurred:
+	// (1) read config.json: oops
+	// (2) read data.json: whoops
+	// true true false
+}
+
+func ExampleFirst() {
+	err := multierr.Combine(oops, whoops, whoopsie)
+
+	fmt.Println(multierr.First(nil), multierr.Last(nil))
+	fmt.Println(multierr.First(oops), multierr.Last(oops))
+	fmt.Println(multierr.First(err), multierr.Last(err))
+
+	// Output: <nil> <nil>
+	// oops oops
+	// oops whoopsie
+}
+
+func ExampleAt() {
+	err := multierr.Combine(oops, whoops, whoopsie)
+	for i := 0; i < multierr.Len(err); i++ {
+		fmt.Println(i, multierr.At(err, i))
+	}
+	fmt.Println(multierr.At(oops, 0))
+
+	defer func() {
+		fmt.Println(recover())
+	}()
+	multierr.At(oops, 1)
+
+	// Output: 0 oops
+	// 1 whoops
+	// 2 whoopsie
+	// oops
+	// multierr.At: index 1 out of range [0:1]
+}
+
+// foreignMultierror mimics *multierror.Error from github.com/hashicorp/go-multierror.
+type foreignMultierror struct {
+	errs []error
+}
+
+func (e *foreignMultierror) Error() string {
+	return fmt.Sprintf("%d foreign errors", len(e.errs))
+}
+
+func (e *foreignMultierror) WrappedErrors() []error {
+	return e.errs
+}
+
+func ExampleAppend_foreign() {
+	foreign := &foreignMultierror{[]error{whoops, whoopsie}}
+
+	fmt.Println(multierr.All(multierr.Append(oops, foreign)))
+	fmt.Println(multierr.All(multierr.Append(foreign, oops)))
+	fmt.Println(multierr.All(multierr.Combine(foreign)))
+	fmt.Println(multierr.Append(nil, foreign) == foreign)
+
+	// Output: [oops whoops whoopsie]
+	// [whoops whoopsie oops]
+	// [whoops whoopsie]
+	// true
+}
+
+func ExampleAppend_join() {
+	joined := errors.Join(oops, whoops)
+
+	fmt.Println(multierr.Len(multierr.Combine(joined)))
+	fmt.Println(multierr.All(multierr.Append(joined, whoopsie)))
+	fmt.Println(multierr.All(multierr.Append(whoopsie, joined)))
+
+	// Output: 2
+	// [oops whoops whoopsie]
+	// [whoopsie oops whoops]
+}
+
+func ExampleAppendAll() {
+	errs := []error{nil, whoops, multierr.Append(whoopsie, io.EOF), nil, errors.Join(io.ErrClosedPipe, io.ErrNoProgress)}
+
+	fmt.Println(multierr.All(multierr.AppendAll(oops, errs...)))
+	fmt.Println(multierr.AppendAll(nil, nil, oops) == oops)
+	fmt.Println(multierr.AppendAll(oops, nil, nil) == oops)
+
+	// Output: [oops whoops whoopsie EOF io: read/write on closed pipe multiple Read calls return no data or error]
+	// true
+	// true
+}
+
+func benchmarkErrors(n int) []error {
+	errs := make([]error, n)
+	for i := range errs {
+		errs[i] = fmt.Errorf("failure %d", i)
+	}
+	return errs
+}
+
+func BenchmarkAppend(b *testing.B) {
+	errs := benchmarkErrors(16)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var err error
+		for _, e := range errs {
+			err = multierr.Append(err, e)
+		}
+	}
+}
+
+func BenchmarkAppendAll(b *testing.B) {
+	errs := benchmarkErrors(16)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = multierr.AppendAll(nil, errs...)
+	}
+}
+
+func BenchmarkCombine(b *testing.B) {
+	errs := benchmarkErrors(16)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = multierr.Combine(errs...)
+	}
+}
+
+func ExampleHasErrors() {
+	fmt.Println(multierr.IsEmpty(nil), multierr.HasErrors(nil))
+	fmt.Println(multierr.IsEmpty(oops), multierr.HasErrors(oops))
+	fmt.Println(multierr.IsEmpty(multierr.Append(oops, whoops)), multierr.HasErrors(multierr.Append(oops, whoops)))
+
+	// Output: true false
+	// false true
+	// false true
+}
+
+func ExampleAppendNew() {
+	shared := multierr.Combine(oops, whoops)
+	shared = multierr.Append(shared, whoopsie) // leaves spare capacity
+
+	a := multierr.AppendNew(shared, io.EOF)
+	b := multierr.AppendNew(shared, io.ErrUnexpectedEOF)
+
+	fmt.Println(multierr.All(a))
+	fmt.Println(multierr.All(b))
+	fmt.Println(multierr.Len(shared))
+
+	storage := func(err error) *error {
+		return &err.(interface{ Unwrap() []error }).Unwrap()[0]
+	}
+	fmt.Println(storage(a) != storage(b), storage(a) != storage(shared))
+
+	// Output: [oops whoops whoopsie EOF]
+	// [oops whoops whoopsie unexpected EOF]
+	// 3
+	// true true
+}
+
+func ExampleMultierror() {
+	err := fmt.Errorf("upload: %w", multierr.Append(oops, whoops))
+
+	var m multierr.Multierror
+	fmt.Println(errors.As(err, &m))
+	fmt.Println(m.Errors())
+
+	fmt.Println(errors.As(oops, &m))
+
+	// Output: true
+	// [oops whoops]
+	// false
+}
+
+func ExampleErrorser() {
+	err := multierr.Append(oops, whoops)
+
+	if m, ok := err.(multierr.Errorser); ok {
+		for _, sub := range m.Errors() {
+			fmt.Println(sub)
+		}
+	}
+
+	_, ok := error(oops).(multierr.Errorser)
+	fmt.Println(ok)
+
+	// Output: oops
+	// whoops
+	// false
+}
+
+func ExampleCollectFunc() {
+	type result struct {
+		name string
+		err  error
+	}
+	results := []result{{"a", nil}, {"b", oops}, {"c", nil}, {"d", whoops}}
+
+	err := multierr.CollectFunc(results, func(r result) error {
+		if r.err != nil {
+			return fmt.Errorf("%s: %w", r.name, r.err)
+		}
+		return nil
+	})
+	fmt.Println(err)
+
+	// Output: 2 errors occurred:
+	// (1) b: oops
+	// (2) d: whoops
+}
+
+func ExampleForEachUntil() {
+	err := multierr.Combine(oops, whoops, whoopsie)
+	multierr.ForEachUntil(err, func(err error) bool {
+		fmt.Println(err)
+		return err != whoops
+	})
+
+	// Output: oops
+	// whoops
+}
+
+func ExampleJoin() {
+	fmt.Println(multierr.Join(nil, nil))
+	fmt.Println(multierr.Join(nil, oops) == oops)
+	fmt.Println(multierr.All(multierr.Join(oops, multierr.Append(whoops, whoopsie))))
+	fmt.Println(multierr.Join(oops, whoops).Error() == multierr.Combine(oops, whoops).Error())
+
+	// Output: <nil>
+	// true
+	// [oops whoops whoopsie]
+	// true
+}
+
+func ExampleAppendIf() {
+	var err error
+	err = multierr.AppendIf(err, true, oops)
+	err = multierr.AppendIf(err, false, whoops)
+	err = multierr.AppendIf(err, true, nil)
+	fmt.Println(err == oops)
+
+	err = multierr.AppendIf(err, true, whoopsie)
+	fmt.Println(multierr.All(err))
+
+	// Output: true
+	// [oops whoopsie]
+}
+
+type cyclicError struct {
+	next error
+}
+
+func (e *cyclicError) Error() string { return "cyclic" }
+func (e *cyclicError) Unwrap() error { return e.next }
+
+func ExampleForEachLeaf() {
+	cyclic := &cyclicError{}
+	cyclic.next = &cyclicError{cyclic}
+
+	err := multierr.Combine(
+		fmt.Errorf("load: %w", fmt.Errorf("open: %w", os.ErrNotExist)),
+		errors.Join(oops, fmt.Errorf("close: %w", whoops)),
+		whoopsie,
+		cyclic,
+	)
+	multierr.ForEachLeaf(err, func(err error) {
+		fmt.Println(err)
+	})
+
+	// Output: file does not exist
+	// oops
+	// whoops
+	// whoopsie
+	// cyclic
+}
+
+func TestMulti_empty(t *testing.T) {
+	var err error = multierr.Multi{}
+	if a, e := err.Error(), "(no errors)"; a != e {
+		t.Errorf("Error() = %q, wanted %q", a, e)
+	}
+	if a, e := fmt.Sprint(err), "(no errors)"; a != e {
+		t.Errorf("Sprint = %q, wanted %q", a, e)
+	}
+}
+
+func BenchmarkAppend_two(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var err error
+		err = multierr.Append(err, oops)
+		err = multierr.Append(err, whoops)
+		if err == nil {
+			b.Fatal("nil")
+		}
+	}
+}
+
+func TestAppend_two(t *testing.T) {
+	err := multierr.Append(oops, whoops)
+	err = multierr.Append(err, whoopsie)
+	err2 := multierr.Append(multierr.Append(oops, whoops), errors.Join(whoopsie))
+
+	for _, err := range []error{err, err2} {
+		if a, e := fmt.Sprint(multierr.All(err)), "[oops whoops whoopsie]"; a != e {
+			t.Errorf("All = %s, wanted %s", a, e)
+		}
+	}
+}
+
+func ExampleAsMulti() {
+	err := multierr.AsMulti(oops)
+
+	_, ok := err.(multierr.Multierror)
+	fmt.Println(ok, err)
+
+	_, ok = error(oops).(multierr.Multierror)
+	fmt.Println(ok)
+
+	fmt.Println(multierr.Len(err), multierr.AsMulti(nil))
+
+	// Output: true oops
+	// false
+	// 1 <nil>
+}
+
+func ExampleAppend_precision() {
+	err := multierr.Combine(oops, whoops, whoopsie, io.EOF, io.ErrUnexpectedEOF)
+
+	fmt.Printf("%.2v\n", err)
+	fmt.Printf("%.0v\n", err)
+	fmt.Printf("%.9v\n", multierr.Append(oops, whoops))
+
+	// Output: 5 errors occurred:
+	// (1) oops
+	// (2) whoops
+	// (3) and 3 more errors
+	// and 5 more errors
+	// 2 errors occurred:
+	// (1) oops
+	// (2) whoops
+}
+
+func ExampleAppend_verbs() {
+	err := multierr.Append(oops, whoops)
+
+	fmt.Println(fmt.Sprintf("%x", err) == fmt.Sprintf("%x", err.Error()))
+	fmt.Printf("[%40v]\n", err)
+	fmt.Printf("[%-52.1s]\n", multierr.Append(err, whoopsie))
+
+	// Output: true
+	// [  2 errors occurred:
+	// (1) oops
+	// (2) whoops]
+	// [3 errors occurred:
+	// (1) oops
+	// (2) and 2 more errors   ]
+}
+
+func ExampleErrMultiple() {
+	fmt.Println(errors.Is(oops, multierr.ErrMultiple))
+	fmt.Println(errors.Is(multierr.Append(oops, whoops), multierr.ErrMultiple))
+	fmt.Println(errors.Is(fmt.Errorf("upload: %w", multierr.Append(oops, whoops)), multierr.ErrMultiple))
+
+	// Output: false
+	// true
+	// true
+}
+
+func ExampleEachIndexed() {
+	files := []string{"a.txt", "b.txt", "c.txt"}
+	err := multierr.Combine(oops, whoops, whoopsie)
+	multierr.EachIndexed(err, func(i int, err error) {
+		fmt.Println(i, files[i], err)
+	})
+
+	// Output: 0 a.txt oops
+	// 1 b.txt whoops
+	// 2 c.txt whoopsie
+}
+
+func ExampleMustAppend() {
+	fmt.Println(multierr.MustAppend(nil, oops))
+	fmt.Println(multierr.MustAppend(oops, whoops))
+
+	defer func() {
+		fmt.Println(recover())
+	}()
+	multierr.MustAppend(nil, nil)
+
+	// Output: oops
+	// 2 errors occurred:
+	// (1) oops
+	// (2) whoops
+	// multierr.MustAppend: no error to record
+}
+
+func ExampleHeadTail() {
+	fmt.Println(multierr.HeadTail(multierr.Combine(oops, whoops, whoopsie)))
+	fmt.Println(multierr.HeadTail(multierr.Combine(oops, whoops)))
+	fmt.Println(multierr.HeadTail(oops))
+	fmt.Println(multierr.HeadTail(nil))
+
+	// Output: oops 2 errors occurred:
+	// (1) whoops
+	// (2) whoopsie
+	// oops whoops
+	// oops <nil>
+	// <nil> <nil>
+}
+
+func ExampleAppendToSlice() {
+	dst := make([]error, 0, 8)
+	dst = append(dst, io.EOF)
+	dst = multierr.AppendToSlice(multierr.Append(oops, whoops), dst)
+	dst = multierr.AppendToSlice(nil, dst)
+	dst = multierr.AppendToSlice(whoopsie, dst)
+
+	fmt.Println(dst, cap(dst))
+	// Output: [EOF oops whoops whoopsie] 8
+}
+
+func ExampleCoalesce() {
+	fmt.Println(multierr.Coalesce(nil, oops, whoops))
+	fmt.Println(multierr.Coalesce(whoops, oops))
+	fmt.Println(multierr.Coalesce(nil, nil))
+	fmt.Println(multierr.Coalesce())
+
+	// Output: oops
+	// whoops
+	// <nil>
+	// <nil>
+}
+
+func ExampleMerge() {
+	err := multierr.Merge(oops, whoops, oops, nil, multierr.Append(whoops, whoopsie), errors.New("oops"))
+	fmt.Println(multierr.All(err))
+
+	fmt.Println(multierr.Merge(oops, oops) == oops)
+
+	// Output: [oops whoops whoopsie oops]
+	// true
+}
+
+func ExampleAppend_isSubset() {
+	err := multierr.Combine(oops, fmt.Errorf("wrapped: %w", whoops), whoopsie)
+
+	fmt.Println(errors.Is(err, multierr.Append(whoops, oops)))
+	fmt.Println(errors.Is(err, multierr.Append(oops, io.EOF)))
+	fmt.Println(errors.Is(oops, multierr.Append(oops, whoops)))
+	fmt.Println(errors.Is(fmt.Errorf("upload: %w", err), multierr.Append(whoops, oops)))
+	fmt.Println(errors.Is(multierr.Wrap(err, "upload"), multierr.Append(whoops, oops)))
+
+	// Output: true
+	// false
+	// false
+	// true
+	// true
+}
+
+func TestAppend_emptyForeign(t *testing.T) {
+	empty := &foreignMultierror{[]error{nil}}
+
+	for name, err := range map[string]error{
+		"Combine":   multierr.Combine(empty),
+		"FromSlice": multierr.FromSlice([]error{empty}),
+		"AppendAll": multierr.AppendAll(nil, empty),
+	} {
+		if err != empty {
+			t.Errorf("%s = %v, wanted the empty foreign multierror itself", name, err)
+		}
+	}
+
+	err := multierr.Append(oops, empty)
+	if a, e := multierr.Len(err), 2; a != e {
+		t.Errorf("Len(Append(oops, empty)) = %d, wanted %d", a, e)
+	}
+	err = multierr.Combine(oops, empty, whoops)
+	if a, e := fmt.Sprint(multierr.All(err)), "[oops 1 foreign errors whoops]"; a != e {
+		t.Errorf("All(Combine(oops, empty, whoops)) = %s, wanted %s", a, e)
+	}
+}
+
+func ExampleAppend_multipleWrapVerbs() {
+	synced := fmt.Errorf("sync %w with %w", whoops, whoopsie)
+	err := multierr.Append(oops, synced)
+
+	fmt.Println(multierr.Len(err))
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, whoopsie))
+
+	// Output: 2
+	// 2 errors occurred:
+	// (1) oops
+	// (2) sync whoops with whoopsie
+	// true
+}
@@ -0,0 +1,65 @@
+package multierr
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// maxStackDepth is the maximum number of frames captured by AppendWithStack.
+const maxStackDepth = 32
+
+// AppendWithStack is like Append, but records the call stack of the caller
+// along with err, to help find out where the error was collected. The stack is
+// available via a StackTrace() []uintptr method of the appended error, and is
+// printed when formatting with %+v. If err is a multierror, each of its
+// suberrors gets the stack. A nil err is a no-op.
+//
+// The original error remains reachable via errors.Is and errors.As.
+func AppendWithStack(dest error, err error) error {
+	if err == nil {
+		return dest
+	}
+	pcs := make([]uintptr, maxStackDepth)
+	pcs = pcs[:runtime.Callers(2, pcs)]
+	return Append(dest, Map(err, func(err error) error {
+		return &stacked{err, pcs}
+	}))
+}
+
+type stacked struct {
+	err error
+	pcs []uintptr
+}
+
+func (e *stacked) Error() string {
+	return e.err.Error()
+}
+
+func (e *stacked) Unwrap() error {
+	return e.err
+}
+
+// StackTrace returns the program counters of the stack captured by
+// AppendWithStack, suitable for runtime.CallersFrames.
+func (e *stacked) StackTrace() []uintptr {
+	return e.pcs
+}
+
+func (e *stacked) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			fmt.Fprintf(f, "%+v", e.err)
+			frames := runtime.CallersFrames(e.pcs)
+			for {
+				frame, more := frames.Next()
+				fmt.Fprintf(f, "\n%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+				if !more {
+					break
+				}
+			}
+			return
+		}
+	}
+	fmt.Fprintf(f, fmt.FormatString(f, verb), e.err)
+}
@@ -0,0 +1,53 @@
+package multierr_test
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/andreyvit/multierr"
+)
+
+func TestAppendWithStack(t *testing.T) {
+	err := multierr.AppendWithStack(oops, whoops)
+	err = multierr.AppendWithStack(err, nil)
+
+	if a, e := multierr.Len(err), 2; a != e {
+		t.Fatalf("Len = %d, wanted %d", a, e)
+	}
+	if !errors.Is(err, whoops) {
+		t.Errorf("errors.Is(err, whoops) = false, wanted true")
+	}
+	if a, e := multierr.Last(err).Error(), "whoops"; a != e {
+		t.Errorf("Error() = %q, wanted %q", a, e)
+	}
+
+	st, ok := multierr.Last(err).(interface{ StackTrace() []uintptr })
+	if !ok {
+		t.Fatalf("appended error does not have StackTrace()")
+	}
+	frame, _ := runtime.CallersFrames(st.StackTrace()).Next()
+	if e := "multierr_test.TestAppendWithStack"; !strings.HasSuffix(frame.Function, e) {
+		t.Errorf("first frame = %s, wanted %s", frame.Function, e)
+	}
+
+	verbose := fmt.Sprintf("%+v", err)
+	if e := "multierr_test.TestAppendWithStack\n\t"; !strings.Contains(verbose, e) {
+		t.Errorf("%%+v = %q, wanted it to contain %q", verbose, e)
+	}
+	if a := fmt.Sprintf("%v", err); strings.Contains(a, "TestAppendWithStack") {
+		t.Errorf("%%v = %q, wanted no stack trace", a)
+	}
+}
+
+func TestAppendWithStack_verbs(t *testing.T) {
+	plain := errors.New("ab")
+	err := multierr.AppendWithStack(nil, plain)
+	for _, format := range []string{"%v", "%s", "%q", "%x", "%6v", "%-6s|", "%.1s"} {
+		if a, e := fmt.Sprintf(format, err), fmt.Sprintf(format, plain); a != e {
+			t.Errorf("%s = %q, wanted %q", format, a, e)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package multierr
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Filter returns err with only the suberrors for which keep returns true,
+// collapsing the result to a single error or nil as appropriate. keep is called
+// once for each suberror, in order. If every suberror is kept, err is returned
+// as is. The argument is not modified.
+func Filter(err error, keep func(err error) bool) error {
+	var kept []error
+	var dropped bool
+	ForEach(err, func(err error) {
+		if keep(err) {
+			kept = append(kept, err)
+		} else {
+			dropped = true
+		}
+	})
+	if !dropped {
+		return err
+	}
+	return collapse(kept)
+}
+
+// Partition splits the suberrors of err into those for which pred returns true
+// and the rest, collapsing each group to a single error or nil as appropriate.
+// pred is called once for each suberror, in order. The argument is not modified.
+func Partition(err error, pred func(err error) bool) (match, rest error) {
+	var matched, others []error
+	ForEach(err, func(err error) {
+		if pred(err) {
+			matched = append(matched, err)
+		} else {
+			others = append(others, err)
+		}
+	})
+	return collapse(matched), collapse(others)
+}
+
+// Remove returns err without the suberrors that match target according to
+// errors.Is, collapsing the result to a single error or nil as appropriate.
+// If nothing matches, err is returned as is. The argument is not modified.
+func Remove(err, target error) error {
+	return Filter(err, func(err error) bool {
+		return !errors.Is(err, target)
+	})
+}
+
+// Flatten expands nested multierrors within err, including the foreign ones
+// recognized by Append (like the ones returned by errors.Join), into a single
+// flat list of suberrors, collapsing the result to a single error or nil
+// as appropriate. Afterwards, Len and All see the actual leaf errors.
+// The argument is not modified.
+func Flatten(err error) error {
+	if _, ok := unwrapMulti(err); !ok {
+		return err
+	}
+	return collapse(appendFlattened(nil, err))
+}
+
+func appendFlattened(errs []error, err error) []error {
+	if sub, ok := unwrapMulti(err); ok {
+		for _, err := range sub {
+			errs = appendFlattened(errs, err)
+		}
+		return errs
+	} else if err != nil {
+		return append(errs, err)
+	} else {
+		return errs
+	}
+}
+
+// Deduplicate returns err without repeated suberrors, keeping the first
+// occurrence of each. Two suberrors are considered duplicates if their Error()
+// messages are identical, which also covers the same error value appended
+// multiple times. The argument is not modified.
+func Deduplicate(err error) error {
+	seen := make(map[string]bool)
+	return Filter(err, func(err error) bool {
+		msg := err.Error()
+		if seen[msg] {
+			return false
+		}
+		seen[msg] = true
+		return true
+	})
+}
+
+// DedupeIs returns err keeping only the first suberror matching each of the
+// sentinels according to errors.Is, and dropping the later ones. Suberrors
+// that match none of the sentinels are kept. The argument is not modified.
+func DedupeIs(err error, sentinels ...error) error {
+	seen := make([]bool, len(sentinels))
+	return Filter(err, func(err error) bool {
+		for i, sentinel := range sentinels {
+			if errors.Is(err, sentinel) {
+				if seen[i] {
+					return false
+				}
+				seen[i] = true
+				return true
+			}
+		}
+		return true
+	})
+}
+
+// Map returns err with each suberror replaced by the result of f, collapsing
+// the result to a single error or nil as appropriate. Suberrors for which f
+// returns nil are dropped, and multierrors returned by f (including the foreign
+// ones recognized by Append) are flattened. For a non-multierror err, f is
+// called once, with err itself. The argument is not modified.
+func Map(err error, f func(err error) error) error {
+	var result []error
+	ForEach(err, func(err error) {
+		if err := f(err); err != nil {
+			result = appendUnwrapped(result, err)
+		}
+	})
+	return collapse(result)
+}
+
+// WithPrefix returns err with each suberror wrapped so that its message
+// becomes prefix + ": " + the original message. The original suberrors remain
+// reachable via errors.Is and errors.As. Returns nil if err is nil.
+func WithPrefix(err error, prefix string) error {
+	return Map(err, func(err error) error {
+		return fmt.Errorf("%s: %w", prefix, err)
+	})
+}
+
+// Sort returns err with suberrors sorted lexicographically by their messages.
+// Non-multierror values are returned as is. The argument is not modified.
+func Sort(err error) error {
+	m, ok := toMulti(err)
+	if !ok {
+		return err
+	}
+	sorted := make(multi, len(m))
+	copy(sorted, m)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Error() < sorted[j].Error()
+	})
+	return sorted
+}
+
+// Wrap returns an error whose message is msg + ": " + err.Error(), with the
+// suberrors of err remaining reachable via errors.Is and errors.As (through
+// Unwrap() []error). Returns nil if err is nil.
+//
+// Like other errors carrying their own message, the result of Wrap is not
+// flattened by Append, so that msg is kept.
+func Wrap(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	return &wrapper{msg, err}
+}
+
+type wrapper struct {
+	msg string
+	err error
+}
+
+func (w *wrapper) Error() string {
+	return w.msg + ": " + w.err.Error()
+}
+
+func (w *wrapper) Unwrap() []error {
+	return All(w.err)
+}
+
+// Is matches target against the wrapped multierror as a whole, which Unwrap
+// doesn't expose itself, so that the result of Wrap matches ErrMultiple and
+// other multierrors (with subset semantics) just like the wrapped one does.
+func (w *wrapper) Is(target error) bool {
+	m, ok := toMulti(w.err)
+	return ok && m.Is(target)
+}
+
+// As allows to obtain the wrapped multierror as a Multierror or an Errorser.
+func (w *wrapper) As(target any) bool {
+	m, ok := w.err.(Multierror)
+	if !ok {
+		return false
+	}
+	switch t := target.(type) {
+	case *Multierror:
+		*t = m
+		return true
+	case *Errorser:
+		*t = m
+		return true
+	default:
+		return false
+	}
+}
+
+// Reverse returns err with suberrors in reverse order.
+// Non-multierror values are returned as is. The argument is not modified.
+func Reverse(err error) error {
+	m, ok := toMulti(err)
+	if !ok {
+		return err
+	}
+	reversed := make(multi, len(m))
+	for i, err := range m {
+		reversed[len(m)-1-i] = err
+	}
+	return reversed
+}
+
+// Clone returns a shallow copy of err if it's a multierror, so that appending
+// to either the copy or the original (which Append may do in place) does not
+// affect the other. Non-multierror values are returned as is.
+func Clone(err error) error {
+	m, ok := toMulti(err)
+	if !ok {
+		return err
+	}
+	clone := make(multi, len(m))
+	copy(clone, m)
+	return clone
+}
+
+// Normalize returns err in a form that Append can never modify in place:
+// a multierror whose capacity is exactly its length, copying it if needed.
+// Use it before storing an aggregated error for a long time, e.g. in a cache.
+// Unlike Clone, it doesn't copy when it's not necessary.
+// Non-multierror values are returned as is.
+func Normalize(err error) error {
+	m, ok := toMulti(err)
+	if !ok || cap(m) == len(m) {
+		return err
+	}
+	return Clone(err)
+}
+
+// Truncate returns err with at most n suberrors, replacing the rest with
+// a trailing "and M more errors" error. If n <= 0, returns only that trailing
+// error for non-nil err, so that a failure is never turned into success.
+// The argument is not modified.
+func Truncate(err error, n int) error {
+	total := Len(err)
+	if total == 0 || total <= n {
+		return err
+	}
+	if n < 0 {
+		n = 0
+	}
+	result := make([]error, 0, n+1)
+	result = append(result, All(err)[:n]...)
+	result = append(result, moreErrors(total-n))
+	return collapse(result)
+}
@@ -0,0 +1,240 @@
+package multierr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/andreyvit/multierr"
+)
+
+func ExampleRemove() {
+	err := multierr.Combine(oops, whoops, whoopsie)
+
+	fmt.Println(multierr.Remove(oops, oops))
+	fmt.Println(multierr.All(multierr.Remove(err, whoops)))
+	fmt.Println(multierr.All(multierr.Remove(err, io.EOF)))
+	fmt.Println(multierr.All(err))
+
+	// Output: <nil>
+	// [oops whoopsie]
+	// [oops whoops whoopsie]
+	// [oops whoops whoopsie]
+}
+
+func ExampleFilter() {
+	err := multierr.Combine(oops, whoops, whoopsie)
+	startsWithW := func(err error) bool {
+		return strings.HasPrefix(err.Error(), "w")
+	}
+
+	fmt.Println(multierr.Filter(err, func(error) bool { return false }))
+	fmt.Println(multierr.Filter(err, func(err error) bool { return err == oops }) == oops)
+	fmt.Println(multierr.All(multierr.Filter(err, startsWithW)))
+	fmt.Println(multierr.All(err))
+
+	// Output: <nil>
+	// true
+	// [whoops whoopsie]
+	// [oops whoops whoopsie]
+}
+
+func ExampleFlatten() {
+	err := errors.Join(oops, errors.Join(whoops, multierr.Append(whoopsie, io.EOF)))
+	fmt.Println(multierr.Len(err))
+
+	err = multierr.Flatten(err)
+	fmt.Println(multierr.Len(err))
+	fmt.Println(multierr.All(err))
+
+	// Output: 1
+	// 4
+	// [oops whoops whoopsie EOF]
+}
+
+func ExampleDeduplicate() {
+	err := multierr.Combine(oops, whoops, oops, errors.New("whoops"), whoopsie)
+	fmt.Println(multierr.All(multierr.Deduplicate(err)))
+
+	fmt.Println(multierr.Deduplicate(multierr.Append(oops, oops)) == oops)
+
+	// Output: [oops whoops whoopsie]
+	// true
+}
+
+func ExampleMap() {
+	err := multierr.Combine(oops, whoops, whoopsie)
+
+	fmt.Println(multierr.Map(err, func(err error) error {
+		return fmt.Errorf("upload: %w", err)
+	}))
+	fmt.Println(multierr.Map(err, func(err error) error {
+		if err == whoops {
+			return nil
+		}
+		return err
+	}))
+	fmt.Println(multierr.All(multierr.Map(multierr.Append(oops, whoops), func(err error) error {
+		return errors.Join(err, io.EOF)
+	})))
+
+	// Output: 3 errors occurred:
+	// (1) upload: oops
+	// (2) upload: whoops
+	// (3) upload: whoopsie
+	// 2 errors occurred:
+	// (1) oops
+	// (2) whoopsie
+	// [oops EOF whoops EOF]
+}
+
+func ExampleWithPrefix() {
+	err := multierr.WithPrefix(multierr.Append(oops, whoops), "upload")
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, whoops))
+
+	fmt.Println(multierr.WithPrefix(oops, "upload"))
+	fmt.Println(multierr.WithPrefix(nil, "upload"))
+
+	// Output: 2 errors occurred:
+	// (1) upload: oops
+	// (2) upload: whoops
+	// true
+	// upload: oops
+	// <nil>
+}
+
+func ExampleSort() {
+	a := multierr.Combine(whoopsie, oops, whoops)
+	b := multierr.Combine(whoops, whoopsie, oops)
+
+	fmt.Println(multierr.Sort(a).Error() == multierr.Sort(b).Error())
+	fmt.Println(multierr.All(multierr.Sort(a)))
+	fmt.Println(multierr.All(a))
+
+	// Output: true
+	// [oops whoops whoopsie]
+	// [whoopsie oops whoops]
+}
+
+func ExamplePartition() {
+	err := multierr.Combine(oops, whoops, whoopsie)
+	startsWithW := func(err error) bool {
+		return strings.HasPrefix(err.Error(), "w")
+	}
+
+	match, rest := multierr.Partition(err, startsWithW)
+	fmt.Println(multierr.All(match), multierr.All(rest))
+
+	match, rest = multierr.Partition(err, func(error) bool { return true })
+	fmt.Println(multierr.All(match), rest)
+
+	match, rest = multierr.Partition(err, func(error) bool { return false })
+	fmt.Println(match, multierr.All(rest))
+
+	// Output: [whoops whoopsie] [oops]
+	// [oops whoops whoopsie] <nil>
+	// <nil> [oops whoops whoopsie]
+}
+
+func ExampleReverse() {
+	err := multierr.Combine(oops, whoops, whoopsie)
+
+	fmt.Println(multierr.All(multierr.Reverse(err)))
+	fmt.Println(multierr.All(err))
+	fmt.Println(multierr.Reverse(oops))
+
+	// Output: [whoopsie whoops oops]
+	// [oops whoops whoopsie]
+	// oops
+}
+
+func ExampleClone() {
+	err := multierr.Combine(oops, whoops)
+	err = multierr.Append(err, whoopsie) // leaves spare capacity
+
+	clone := multierr.Clone(err)
+	a := multierr.Append(clone, io.EOF)
+	b := multierr.Append(err, io.ErrUnexpectedEOF)
+
+	fmt.Println(multierr.Len(err), multierr.Len(clone))
+	fmt.Println(multierr.Last(a))
+	fmt.Println(multierr.Last(b))
+
+	// Output: 3 3
+	// EOF
+	// unexpected EOF
+}
+
+func ExampleTruncate() {
+	err := multierr.Combine(oops, whoops, whoopsie, io.EOF, io.ErrUnexpectedEOF)
+
+	fmt.Println(multierr.Truncate(err, 2))
+	fmt.Println(multierr.Truncate(err, 0))
+	fmt.Println(multierr.Truncate(err, -1))
+	fmt.Println(multierr.Len(multierr.Truncate(err, 5)))
+	fmt.Println(multierr.Truncate(nil, 2))
+	fmt.Println(multierr.Truncate(nil, -1))
+
+	// Output: 3 errors occurred:
+	// (1) oops
+	// (2) whoops
+	// (3) and 3 more errors
+	// and 5 more errors
+	// and 5 more errors
+	// 5
+	// <nil>
+	// <nil>
+}
+
+func ExampleWrap() {
+	err := multierr.Wrap(multierr.Append(oops, whoops), "uploading files")
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, whoops), errors.Is(err, whoopsie))
+
+	var m multierr.Multierror
+	fmt.Println(errors.Is(err, multierr.ErrMultiple), errors.As(err, &m), len(m.Errors()))
+
+	err = multierr.Append(err, whoopsie)
+	fmt.Println(multierr.Len(err))
+
+	fmt.Println(multierr.Wrap(nil, "uploading files"))
+
+	// Output: uploading files: 2 errors occurred:
+	// (1) oops
+	// (2) whoops
+	// true false
+	// true true 2
+	// 2
+	// <nil>
+}
+
+func ExampleDedupeIs() {
+	err := multierr.Combine(
+		fmt.Errorf("read a: %w", io.EOF),
+		oops,
+		fmt.Errorf("read b: %w", io.EOF),
+		oops,
+	)
+	fmt.Println(multierr.All(multierr.DedupeIs(err, io.EOF)))
+
+	// Output: [read a: EOF oops oops]
+}
+
+func ExampleNormalize() {
+	err := multierr.Combine(oops, whoops)
+	err = multierr.Append(err, whoopsie) // leaves spare capacity
+
+	errs := multierr.Normalize(err).(multierr.Errorser).Errors()
+	fmt.Println(len(errs), cap(errs))
+
+	a := multierr.Normalize(err)
+	b := multierr.Normalize(a) // no copy needed
+	fmt.Println(&a.(multierr.Errorser).Errors()[0] == &b.(multierr.Errorser).Errors()[0])
+	fmt.Println(multierr.Normalize(oops) == oops)
+
+	// Output: 3 3
+	// true
+	// true
+}
@@ -0,0 +1,25 @@
+package multierr
+
+// Validate calls each of checks in order, and returns all the errors they
+// return combined into a single error. All checks run even if earlier ones fail.
+//
+//	return multierr.Validate(c.validateName, c.validatePort, c.validatePaths)
+func Validate(checks ...func() error) error {
+	var result error
+	for _, check := range checks {
+		result = Append(result, check())
+	}
+	return result
+}
+
+// ValidateUntilError calls each of checks in order until one of them fails,
+// and returns that error; the remaining checks are not called. Use it instead
+// of Validate when later checks make no sense after a failure.
+func ValidateUntilError(checks ...func() error) error {
+	for _, check := range checks {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package multierr_test
+
+import (
+	"fmt"
+
+	"github.com/andreyvit/multierr"
+)
+
+func ExampleValidate() {
+	var ran []int
+	check := func(i int, err error) func() error {
+		return func() error {
+			ran = append(ran, i)
+			return err
+		}
+	}
+
+	err := multierr.Validate(check(1, oops), check(2, nil), check(3, whoops))
+	fmt.Println(err)
+	fmt.Println(ran)
+
+	// Output: 2 errors occurred:
+	// (1) oops
+	// (2) whoops
+	// [1 2 3]
+}
+
+func ExampleValidateUntilError() {
+	var ran []int
+	check := func(i int, err error) func() error {
+		return func() error {
+			ran = append(ran, i)
+			return err
+		}
+	}
+
+	err := multierr.ValidateUntilError(check(1, nil), check(2, oops), check(3, whoops))
+	fmt.Println(err)
+	fmt.Println(ran)
+
+	fmt.Println(multierr.ValidateUntilError(check(4, nil)))
+
+	// Output: oops
+	// [1 2]
+	// <nil>
+}